@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stefanKnott/mlbtakehome/pkg/handlers"
+)
+
+// parseSeasons parses a season (2021), a range of seasons (2015-2024) or a
+// comma separated list of either
+func parseSeasons(param string) ([]int, error) {
+	seasons := make([]int, 0)
+	for _, part := range strings.Split(param, ",") {
+		bounds := strings.SplitN(strings.TrimSpace(part), "-", 2)
+		first, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid season: %s", part)
+		}
+		last := first
+		if len(bounds) == 2 {
+			last, err = strconv.Atoi(bounds[1])
+			if err != nil || last < first {
+				return nil, fmt.Errorf("invalid season range: %s", part)
+			}
+		}
+		for season := first; season <= last; season++ {
+			seasons = append(seasons, season)
+		}
+	}
+	return seasons, nil
+}
+
+// runBackfill serves `mlbtakehome backfill`, which loads every date of the
+// given seasons into the persistent store
+func runBackfill(args []string) int {
+	flags := flag.NewFlagSet("backfill", flag.ExitOnError)
+	seasonsParam := flags.String("seasons", "", "seasons to backfill, ie. 2021 or 2015-2024")
+	concurrency := flags.Int("concurrency", 4, "number of dates fetched at once")
+	rate := flags.Float64("rate", 5, "maximum upstream requests per second")
+	flags.Parse(args)
+
+	seasons, err := parseSeasons(*seasonsParam)
+	if err == nil && *rate <= 0 {
+		err = errors.New("rate must be positive")
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		flags.Usage()
+		return 2
+	}
+
+	scheduleStore, err := openStore()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
+	defer scheduleStore.Close()
+	handlers.SetScheduleStore(scheduleStore)
+
+	// stop on interrupt, the dates stored so far are kept and skipped on the next run
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err = handlers.Backfill(ctx, seasons, handlers.BackfillOptions{
+		Concurrency: *concurrency,
+		Interval:    time.Duration(float64(time.Second) / *rate),
+		Progress:    os.Stdout,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
+	return 0
+}
@@ -1,18 +1,98 @@
 package main
 
 import (
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/stefanKnott/mlbtakehome/pkg/grpcapi"
 	"github.com/stefanKnott/mlbtakehome/pkg/handlers"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+	"github.com/stefanKnott/mlbtakehome/pkg/store"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	defaultStorePath = "mlbtakehome.db"
+	defaultGRPCPort  = "9090"
+)
+
+// openStore opens the persistent store at STORE_PATH, or the default path when unset
+func openStore() (*store.BoltStore, error) {
+	storePath := os.Getenv("STORE_PATH")
+	if storePath == "" {
+		storePath = defaultStorePath
+	}
+	scheduleStore, err := store.NewBoltStore(storePath)
+	if err != nil {
+		return nil, fmt.Errorf("got err when opening store at %s: %s", storePath, err.Error())
+	}
+	return scheduleStore, nil
+}
+
+// serveGRPC serves the gRPC ScheduleService on GRPC_PORT, or the default port when unset
+func serveGRPC() {
+	port := os.Getenv("GRPC_PORT")
+	if port == "" {
+		port = defaultGRPCPort
+	}
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		fmt.Printf("got err when listening for gRPC on port %s: %s\n", port, err.Error())
+		return
+	}
+	err = grpcapi.NewGRPCServer().Serve(lis)
+	if err != nil {
+		fmt.Printf("got err when serving gRPC: %s\n", err.Error())
+	}
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "backfill":
+			os.Exit(runBackfill(os.Args[2:]))
+		case "schedule":
+			os.Exit(runSchedule(os.Args[2:]))
+		}
+	}
+
+	// open persistent storage, the service still runs against statsapi alone without it
+	scheduleStore, err := openStore()
+	if err != nil {
+		fmt.Println(err.Error())
+	} else {
+		defer scheduleStore.Close()
+		handlers.SetScheduleStore(scheduleStore)
+	}
+
 	// start server
 	handlers.InitTeamIdSet()
+	handlers.InitChangeFeed()
+	go serveGRPC()
 	router := gin.Default()
+	router.GET("/graphql", handlers.ServeGraphQL)
+	router.POST("/graphql", handlers.ServeGraphQL)
 	v1 := router.Group("/api/v1")
+	v1.Use(handlers.RequestValidation())
 	{
-		v1.GET("/schedule", handlers.GetSchedule)
+		v1.GET("/openapi.json", handlers.GetOpenAPI)
+		// fields and view select the fields of the games an API returns
+		v1.GET("/schedule", handlers.FieldSelection(models.Game{}), handlers.GetSchedule)
+		v1.GET("/schedule/changes", handlers.GetScheduleChanges)
+		v1.GET("/seasons/:year", handlers.GetSeason)
+		v1.GET("/postseason", handlers.FieldSelection(handlers.PostseasonGame{}), handlers.GetPostseason)
+		v1.GET("/teams/:id/schedule", handlers.FieldSelection(handlers.TeamScheduleGame{}), handlers.GetTeamSchedule)
+		v1.GET("/standings", handlers.GetStandings)
+		v1.GET("/matchups", handlers.FieldSelection(handlers.TeamScheduleGame{}), handlers.GetMatchups)
+		v1.GET("/venues", handlers.GetVenues)
+		v1.GET("/venues/:id/schedule", handlers.FieldSelection(models.Game{}), handlers.GetVenueSchedule)
+		v1.POST("/subscriptions", handlers.CreateSubscription)
+		v1.GET("/subscriptions", handlers.GetSubscriptions)
+		v1.DELETE("/subscriptions/:id", handlers.DeleteSubscription)
+		v1.GET("/subscriptions/deadletters", handlers.GetDeadLetters)
+		v1.GET("/ws", handlers.GetScoreboardSocket)
 	}
 	router.Run()
 }
@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+const (
+	liveMarker = "*"
+	highlight  = "\x1b[1;32m"
+	reset      = "\x1b[0m"
+)
+
+// scoreboardLine is a single game's row before it is padded into columns
+type scoreboardLine struct {
+	label     string
+	away      string
+	awayScore string
+	home      string
+	homeScore string
+	status    string
+	live      bool
+}
+
+// teamLabel prefers a team's abbreviation, schedule payloads only carry names
+func teamLabel(team models.Team) string {
+	if team.Abbreviation != "" {
+		return team.Abbreviation
+	}
+	return team.Name
+}
+
+// gameLabel numbers the games of a double header
+func gameLabel(g models.Game) string {
+	if g.DoubleHeader == "Y" || g.DoubleHeader == "S" {
+		return fmt.Sprintf("Game %d", g.GameNumber)
+	}
+	return ""
+}
+
+// gameStatus shows the start time of games yet to be played and the state of all others
+func gameStatus(g models.Game) string {
+	if g.Status.AbstractGameCode != "P" {
+		return g.Status.DetailedState
+	}
+	if g.LocalGameTime != "" {
+		return g.LocalGameTime
+	}
+	if g.Status.StartTimeTBD {
+		return "TBD"
+	}
+	t, err := time.Parse(time.RFC3339, g.GameDate)
+	if err != nil {
+		return g.Status.DetailedState
+	}
+	return t.UTC().Format("3:04 PM MST")
+}
+
+func newScoreboardLine(g models.Game) scoreboardLine {
+	line := scoreboardLine{
+		label:  gameLabel(g),
+		away:   teamLabel(g.Teams.Away.Team),
+		home:   teamLabel(g.Teams.Home.Team),
+		status: gameStatus(g),
+		live:   g.Status.AbstractGameCode == "L",
+	}
+	if g.Status.AbstractGameCode != "P" {
+		line.awayScore = strconv.Itoa(int(g.Teams.Away.Score))
+		line.homeScore = strconv.Itoa(int(g.Teams.Home.Score))
+	}
+	return line
+}
+
+// RenderSchedule writes a human readable scoreboard of a schedule's games in
+// the order given, the requested team's games are separated from the rest and
+// live games are marked, and highlighted when color is set
+func RenderSchedule(w io.Writer, teamID int, schedResp *models.ScheduleResponse, color bool) error {
+	for _, d := range schedResp.Dates {
+		header := d.Date
+		t, err := time.Parse("2006-01-02", d.Date)
+		if err == nil {
+			header = t.Format("Monday, January 2, 2006")
+		}
+		_, err = fmt.Fprintf(w, "%s\n\n", header)
+		if err != nil {
+			return err
+		}
+
+		if len(d.Games) == 0 {
+			_, err = fmt.Fprintln(w, "  No games scheduled")
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		lines := make([]scoreboardLine, 0, len(d.Games))
+		widths := make([]int, 5)
+		for _, g := range d.Games {
+			line := newScoreboardLine(g)
+			for i, column := range []string{line.label, line.away, line.awayScore, line.home, line.homeScore} {
+				if len(column) > widths[i] {
+					widths[i] = len(column)
+				}
+			}
+			lines = append(lines, line)
+		}
+
+		for i, line := range lines {
+			g := d.Games[i]
+			if i > 0 {
+				prev := d.Games[i-1]
+				isTeamGame := g.Teams.Away.Team.ID == teamID || g.Teams.Home.Team.ID == teamID
+				wasTeamGame := prev.Teams.Away.Team.ID == teamID || prev.Teams.Home.Team.ID == teamID
+				if wasTeamGame && !isTeamGame {
+					_, err = fmt.Fprintln(w)
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			marker := " "
+			if line.live {
+				marker = liveMarker
+			}
+			row := marker + " "
+			if widths[0] > 0 {
+				// only double headers are labelled
+				row += fmt.Sprintf("%-*s  ", widths[0], line.label)
+			}
+			row += fmt.Sprintf("%-*s %*s  @  %-*s %*s  %s",
+				widths[1], line.away,
+				widths[2], line.awayScore,
+				widths[3], line.home,
+				widths[4], line.homeScore,
+				line.status)
+			row = strings.TrimRight(row, " ")
+			if line.live && color {
+				row = highlight + row + reset
+			}
+			_, err = fmt.Fprintln(w, row)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+func newTestGame(gamePk int, away string, awayId int, home string, homeId int, abstractGameCode string, detailedState string) models.Game {
+	return models.Game{
+		GamePk:   gamePk,
+		GameDate: "2021-09-11T23:05:00Z",
+		Status:   models.Status{AbstractGameCode: abstractGameCode, DetailedState: detailedState},
+		Teams: models.Teams{
+			Away: models.ScheduleTeam{Team: models.Team{ID: awayId, Name: away, Abbreviation: away}},
+			Home: models.ScheduleTeam{Team: models.Team{ID: homeId, Name: home, Abbreviation: home}},
+		},
+		DoubleHeader: "N",
+		GameNumber:   1,
+	}
+}
+
+var _ = Describe("Rendering a scoreboard", Label("Render"), func() {
+	var schedResp *models.ScheduleResponse
+
+	BeforeEach(func() {
+		game1 := newTestGame(1, "NYY", 147, "BAL", 110, "F", "Final")
+		game1.DoubleHeader, game1.GameNumber = "Y", 1
+		game1.Teams.Away.Score, game1.Teams.Home.Score = 5, 3
+		game2 := newTestGame(2, "NYY", 147, "BAL", 110, "L", "In Progress")
+		game2.DoubleHeader, game2.GameNumber = "Y", 2
+		game2.Teams.Away.Score = 1
+		game3 := newTestGame(3, "TOR", 141, "BOS", 111, "P", "Scheduled")
+		game3.LocalGameTime = "7:05 PM EDT"
+
+		schedResp = &models.ScheduleResponse{Dates: []models.Date{{Date: "2021-09-11", Games: []models.Game{game1, game2, game3}}}}
+	})
+
+	When("We render a date with a double header", func() {
+		It("should label the games, mark live games and separate the requested team's games", func(ctx SpecContext) {
+			var out bytes.Buffer
+			Expect(RenderSchedule(&out, 147, schedResp, false)).To(Succeed())
+			Expect(out.String()).To(Equal("" +
+				"Saturday, September 11, 2021\n" +
+				"\n" +
+				"  Game 1  NYY 5  @  BAL 3  Final\n" +
+				"* Game 2  NYY 1  @  BAL 0  In Progress\n" +
+				"\n" +
+				"          TOR    @  BOS    7:05 PM EDT\n"))
+		})
+
+		It("should highlight live games when color is enabled", func(ctx SpecContext) {
+			var out bytes.Buffer
+			Expect(RenderSchedule(&out, 147, schedResp, true)).To(Succeed())
+			Expect(out.String()).To(ContainSubstring(highlight + "* Game 2  NYY 1  @  BAL 0  In Progress" + reset))
+		})
+	})
+
+	When("We render a date without games", func() {
+		It("should say so", func(ctx SpecContext) {
+			var out bytes.Buffer
+			schedResp.Dates[0].Games = make([]models.Game, 0)
+			Expect(RenderSchedule(&out, 147, schedResp, false)).To(Succeed())
+			Expect(out.String()).To(ContainSubstring("No games scheduled"))
+		})
+	})
+
+	When("A team has no abbreviation", func() {
+		It("should fall back to its name", func(ctx SpecContext) {
+			Expect(teamLabel(models.Team{ID: 159, Name: "American League All-Stars"})).To(Equal("American League All-Stars"))
+		})
+	})
+})
@@ -0,0 +1,152 @@
+package grpcapi
+
+import (
+	"github.com/stefanKnott/mlbtakehome/pkg/handlers"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+	pb "github.com/stefanKnott/mlbtakehome/pkg/schedulepb"
+)
+
+// conversions from the models served by the REST API to their protobuf messages
+
+func venueToProto(v models.Venue) *pb.Venue {
+	venue := &pb.Venue{Id: int32(v.ID), Name: v.Name, Link: v.Link}
+	if v.TimeZone != nil {
+		venue.TimeZone = &pb.VenueTimeZone{Id: v.TimeZone.ID, Offset: int32(v.TimeZone.Offset), Tz: v.TimeZone.Tz}
+	}
+	return venue
+}
+
+func teamToProto(t models.Team) *pb.Team {
+	team := &pb.Team{
+		Id:            int32(t.ID),
+		Name:          t.Name,
+		Link:          t.Link,
+		Abbreviation:  t.Abbreviation,
+		TeamName:      t.TeamName,
+		ShortName:     t.ShortName,
+		TeamCode:      t.TeamCode,
+		FileCode:      t.FileCode,
+		ClubName:      t.ClubName,
+		FranchiseName: t.FranchiseName,
+		LocationName:  t.LocationName,
+		SpringLeague:  &pb.SpringLeague{Id: int32(t.SpringLeague.ID), Name: t.SpringLeague.Name},
+	}
+	if t.League != nil {
+		team.League = &pb.League{Id: int32(t.League.ID), Name: t.League.Name, Link: t.League.Link}
+	}
+	if t.Division != nil {
+		team.Division = &pb.Division{Id: int32(t.Division.ID), Name: t.Division.Name, Link: t.Division.Link}
+	}
+	if t.Venue != nil {
+		team.Venue = venueToProto(*t.Venue)
+	}
+	return team
+}
+
+func leagueRecordToProto(r models.LeagueRecord) *pb.LeagueRecord {
+	return &pb.LeagueRecord{Wins: uint32(r.Wins), Losses: uint32(r.Losses), Pct: r.Pct}
+}
+
+func scheduleTeamToProto(t models.ScheduleTeam) *pb.ScheduleTeam {
+	return &pb.ScheduleTeam{
+		LeagueRecord: leagueRecordToProto(t.LeagueRecord),
+		Score:        uint32(t.Score),
+		Team:         teamToProto(t.Team),
+		IsWinner:     t.IsWinner,
+		SplitSquad:   t.SplitSquad,
+		SeriesNumber: uint32(t.SeriesNumber),
+	}
+}
+
+func gameToProto(g models.Game) *pb.Game {
+	game := &pb.Game{
+		GamePk:       int32(g.GamePk),
+		Link:         g.Link,
+		GameType:     g.GameType,
+		Season:       g.Season,
+		GameDate:     g.GameDate,
+		OfficialDate: g.OfficialDate,
+		Status: &pb.Status{
+			AbstractGameState: g.Status.AbstractGameState,
+			AbstractGameCode:  g.Status.AbstractGameCode,
+			CodedGameState:    g.Status.CodedGameState,
+			DetailedState:     g.Status.DetailedState,
+			StatusCode:        g.Status.StatusCode,
+			StartTimeTbd:      g.Status.StartTimeTBD,
+		},
+		Teams:                  &pb.Teams{Away: scheduleTeamToProto(g.Teams.Away), Home: scheduleTeamToProto(g.Teams.Home)},
+		Venue:                  venueToProto(g.Venue),
+		IsTie:                  g.IsTie,
+		GameNumber:             uint32(g.GameNumber),
+		PublicFacing:           g.PublicFacing,
+		DoubleHeader:           g.DoubleHeader,
+		GamedayType:            g.GamedayType,
+		Tiebreaker:             g.Tiebreaker,
+		CalendarEventId:        g.CalendarEventID,
+		SeasonDisplay:          g.SeasonDisplay,
+		DayNight:               g.DayNight,
+		ScheduledInnings:       uint32(g.ScheduledInnings),
+		ReverseHomeAwayStatus:  g.ReverseHomeAwayStatus,
+		InningBreakLength:      uint32(g.InningBreakLength),
+		GamesInSeries:          uint32(g.GamesInSeries),
+		SeriesGameNumber:       uint32(g.SeriesGameNumber),
+		SeriesDescription:      g.SeriesDescription,
+		RecordSource:           g.RecordSource,
+		IfNecessary:            g.IfNecessary,
+		IfNecessaryDescription: g.IfNecessaryDescription,
+		LocalGameDate:          g.LocalGameDate,
+		LocalGameTime:          g.LocalGameTime,
+		LocalTimeZone:          g.LocalTimeZone,
+		IsNeutralSite:          g.IsNeutralSite,
+	}
+	if g.Perspective != nil {
+		game.Perspective = &pb.Perspective{
+			Opponent:      teamToProto(g.Perspective.Opponent),
+			HomeAway:      g.Perspective.HomeAway,
+			Score:         uint32(g.Perspective.Score),
+			OpponentScore: uint32(g.Perspective.OpponentScore),
+			Result:        g.Perspective.Result,
+			Record:        leagueRecordToProto(g.Perspective.Record),
+		}
+	}
+	return game
+}
+
+func dateToProto(d models.Date) *pb.Date {
+	games := make([]*pb.Game, 0, len(d.Games))
+	for _, g := range d.Games {
+		games = append(games, gameToProto(g))
+	}
+	return &pb.Date{
+		Date:                 d.Date,
+		TotalItems:           uint32(d.TotalItems),
+		TotalEvents:          uint32(d.TotalEvents),
+		TotalGames:           uint32(d.TotalGames),
+		TotalGamesInProgress: uint32(d.TotalGamesInProgress),
+		Games:                games,
+	}
+}
+
+func scheduleToProto(s *handlers.ScheduleResponse) *pb.ScheduleResponse {
+	dates := make([]*pb.Date, 0, len(s.Dates))
+	for _, d := range s.Dates {
+		dates = append(dates, dateToProto(d))
+	}
+	resp := &pb.ScheduleResponse{
+		Copyright:            s.Copyright,
+		TotalItems:           uint32(s.TotalItems),
+		TotalEvents:          uint32(s.TotalEvents),
+		TotalGames:           uint32(s.TotalGames),
+		TotalGamesInProgress: uint32(s.TotalGamesInProgress),
+		Dates:                dates,
+		Date:                 s.Date,
+	}
+	if s.OffSeason != nil {
+		resp.OffSeason = &pb.OffSeason{
+			NextSeason:          s.OffSeason.NextSeason,
+			SpringTrainingStart: s.OffSeason.SpringTrainingStart,
+			OpeningDay:          s.OffSeason.OpeningDay,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,12 @@
+package grpcapi
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"testing"
+)
+
+func TestGrpcapi(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Grpcapi Suite")
+}
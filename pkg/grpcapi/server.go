@@ -0,0 +1,144 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/stefanKnott/mlbtakehome/pkg/handlers"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+	pb "github.com/stefanKnott/mlbtakehome/pkg/schedulepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultWatchInterval = 15 * time.Second
+	minWatchInterval     = 5 * time.Second
+)
+
+// ScheduleServer serves the ScheduleService over gRPC using the same schedule
+// logic as the REST API
+type ScheduleServer struct {
+	pb.UnimplementedScheduleServiceServer
+
+	// overridable in tests
+	schedule   func(handlers.ScheduleQuery) (*handlers.ScheduleResponse, error)
+	teams      func() []models.Team
+	lookupTeam func(string) (models.Team, error)
+	// WatchSchedule's interval when a request does not set one, and its lower bound
+	defaultInterval time.Duration
+	minInterval     time.Duration
+}
+
+func NewScheduleServer() *ScheduleServer {
+	return &ScheduleServer{
+		schedule:        handlers.Schedule,
+		teams:           handlers.Teams,
+		lookupTeam:      handlers.LookupTeam,
+		defaultInterval: defaultWatchInterval,
+		minInterval:     minWatchInterval,
+	}
+}
+
+// NewGRPCServer returns a grpc.Server with the ScheduleService registered
+func NewGRPCServer() *grpc.Server {
+	s := grpc.NewServer()
+	pb.RegisterScheduleServiceServer(s, NewScheduleServer())
+	return s
+}
+
+// scheduleError maps an error from handlers.Schedule to its gRPC status
+func scheduleError(err error) error {
+	var invalid *handlers.InvalidQueryError
+	if errors.As(err, &invalid) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
+
+func (s *ScheduleServer) getSchedule(req *pb.GetScheduleRequest) (*pb.ScheduleResponse, error) {
+	if req.GetTeam() == "" {
+		return nil, status.Error(codes.InvalidArgument, "team is required")
+	}
+	team, err := s.lookupTeam(req.GetTeam())
+	if err != nil {
+		// an ambiguous team is an invalid argument rather than a missing one
+		code := codes.InvalidArgument
+		if errors.Is(err, handlers.ErrTeamNotFound) {
+			code = codes.NotFound
+		}
+		return nil, status.Error(code, err.Error())
+	}
+
+	schedResp, err := s.schedule(handlers.ScheduleQuery{
+		TeamID:   team.ID,
+		Date:     req.GetDate(),
+		GameType: req.GetGameType(),
+		Sort:     req.GetSort(),
+		TimeZone: req.GetTimeZone(),
+	})
+	if err != nil {
+		return nil, scheduleError(err)
+	}
+	return scheduleToProto(schedResp), nil
+}
+
+// GetSchedule returns the games scheduled for a date with the requested team's games ordered first
+func (s *ScheduleServer) GetSchedule(ctx context.Context, req *pb.GetScheduleRequest) (*pb.ScheduleResponse, error) {
+	return s.getSchedule(req)
+}
+
+// ListTeams returns the clubs in the team registry ordered by ID
+func (s *ScheduleServer) ListTeams(ctx context.Context, req *pb.ListTeamsRequest) (*pb.ListTeamsResponse, error) {
+	teams := s.teams()
+	resp := &pb.ListTeamsResponse{Teams: make([]*pb.Team, 0, len(teams))}
+	for _, team := range teams {
+		resp.Teams = append(resp.Teams, teamToProto(team))
+	}
+	return resp, nil
+}
+
+// WatchSchedule sends the schedule and then polls it, sending it again each
+// time it changes until the client cancels. A failed poll is skipped rather
+// than ending the stream, only the first lookup's error is returned
+func (s *ScheduleServer) WatchSchedule(req *pb.WatchScheduleRequest, stream pb.ScheduleService_WatchScheduleServer) error {
+	interval := time.Duration(req.GetIntervalSeconds()) * time.Second
+	if interval == 0 {
+		interval = s.defaultInterval
+	}
+	if interval < s.minInterval {
+		interval = s.minInterval
+	}
+
+	last, err := s.getSchedule(req.GetRequest())
+	if err != nil {
+		return err
+	}
+	err = stream.Send(last)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		schedResp, err := s.getSchedule(req.GetRequest())
+		if err != nil || proto.Equal(schedResp, last) {
+			continue
+		}
+		err = stream.Send(schedResp)
+		if err != nil {
+			return err
+		}
+		last = schedResp
+	}
+}
@@ -0,0 +1,165 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/handlers"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+	pb "github.com/stefanKnott/mlbtakehome/pkg/schedulepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var _ = Describe("gRPC schedule service", Label("GRPC"), func() {
+	var (
+		server  *ScheduleServer
+		grpcSrv *grpc.Server
+		conn    *grpc.ClientConn
+		client  pb.ScheduleServiceClient
+
+		lock          sync.Mutex
+		queries       []handlers.ScheduleQuery
+		detailedState string
+	)
+
+	yankees := models.Team{ID: 147, Name: "New York Yankees", Abbreviation: "NYY", Venue: &models.Venue{ID: 3313, Name: "Yankee Stadium"}}
+	redSox := models.Team{ID: 111, Name: "Boston Red Sox", Abbreviation: "BOS"}
+
+	BeforeEach(func() {
+		queries = nil
+		detailedState = "Scheduled"
+
+		server = NewScheduleServer()
+		server.defaultInterval, server.minInterval = 10*time.Millisecond, 10*time.Millisecond
+		server.teams = func() []models.Team { return []models.Team{redSox, yankees} }
+		server.lookupTeam = func(query string) (models.Team, error) {
+			switch query {
+			case "147", "NYY":
+				return yankees, nil
+			case "Sox":
+				return models.Team{}, errors.New(`ambiguous team "Sox", could be: Boston Red Sox, Chicago White Sox`)
+			}
+			return models.Team{}, fmt.Errorf("%w: %s", handlers.ErrTeamNotFound, query)
+		}
+		server.schedule = func(q handlers.ScheduleQuery) (*handlers.ScheduleResponse, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			queries = append(queries, q)
+			switch q.Date {
+			case "bogus":
+				return nil, &handlers.InvalidQueryError{Err: errors.New("invalid date: bogus")}
+			case "2021-09-12":
+				return nil, errors.New("statsapi unreachable")
+			}
+			game := models.Game{GamePk: 1, GameDate: "2021-09-11T23:05:00Z", Status: models.Status{DetailedState: detailedState}}
+			game.Teams.Away.Team, game.Teams.Home.Team = yankees, redSox
+			game.Perspective = &models.Perspective{Opponent: redSox, HomeAway: "away"}
+			return &handlers.ScheduleResponse{
+				ScheduleResponse: models.ScheduleResponse{Copyright: "MLB", Dates: []models.Date{{Date: "2021-09-11", Games: []models.Game{game}}}},
+				Date:             "2021-09-11",
+			}, nil
+		}
+
+		lis := bufconn.Listen(1024 * 1024)
+		grpcSrv = grpc.NewServer()
+		pb.RegisterScheduleServiceServer(grpcSrv, server)
+		go grpcSrv.Serve(lis)
+
+		var err error
+		conn, err = grpc.Dial("bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()))
+		Expect(err).To(BeNil())
+		client = pb.NewScheduleServiceClient(conn)
+	})
+
+	AfterEach(func() {
+		conn.Close()
+		grpcSrv.Stop()
+	})
+
+	setStatus := func(s string) {
+		lock.Lock()
+		detailedState = s
+		lock.Unlock()
+	}
+
+	When("We get a schedule", func() {
+		It("should return the same games as the REST API", func(ctx SpecContext) {
+			resp, err := client.GetSchedule(ctx, &pb.GetScheduleRequest{Team: "NYY", Date: "2021-09-11", Sort: "time", TimeZone: "America/New_York"})
+			Expect(err).To(BeNil())
+			Expect(resp.GetDate()).To(Equal("2021-09-11"))
+			Expect(resp.GetDates()).To(HaveLen(1))
+			game := resp.GetDates()[0].GetGames()[0]
+			Expect(game.GetGamePk()).To(Equal(int32(1)))
+			Expect(game.GetTeams().GetAway().GetTeam().GetAbbreviation()).To(Equal("NYY"))
+			Expect(game.GetTeams().GetAway().GetTeam().GetVenue().GetName()).To(Equal("Yankee Stadium"))
+			Expect(game.GetPerspective().GetOpponent().GetId()).To(Equal(int32(111)))
+			Expect(queries).To(Equal([]handlers.ScheduleQuery{{TeamID: 147, Date: "2021-09-11", Sort: "time", TimeZone: "America/New_York"}}))
+		})
+
+		It("should map errors to status codes", func(ctx SpecContext) {
+			for req, code := range map[*pb.GetScheduleRequest]codes.Code{
+				{}:                                codes.InvalidArgument,
+				{Team: "Mets"}:                    codes.NotFound,
+				{Team: "Sox"}:                     codes.InvalidArgument,
+				{Team: "147", Date: "bogus"}:      codes.InvalidArgument,
+				{Team: "147", Date: "2021-09-12"}: codes.Unavailable,
+			} {
+				_, err := client.GetSchedule(ctx, req)
+				Expect(status.Code(err)).To(Equal(code), req.String())
+			}
+		})
+	})
+
+	When("We list teams", func() {
+		It("should return the team registry", func(ctx SpecContext) {
+			resp, err := client.ListTeams(ctx, &pb.ListTeamsRequest{})
+			Expect(err).To(BeNil())
+			Expect(resp.GetTeams()).To(HaveLen(2))
+			Expect(resp.GetTeams()[1].GetName()).To(Equal("New York Yankees"))
+		})
+	})
+
+	When("We watch a schedule", func() {
+		It("should send the schedule and then each change to it", func(ctx SpecContext) {
+			watchCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			stream, err := client.WatchSchedule(watchCtx, &pb.WatchScheduleRequest{Request: &pb.GetScheduleRequest{Team: "147", Date: "2021-09-11"}})
+			Expect(err).To(BeNil())
+
+			resp, err := stream.Recv()
+			Expect(err).To(BeNil())
+			Expect(resp.GetDates()[0].GetGames()[0].GetStatus().GetDetailedState()).To(Equal("Scheduled"))
+
+			// unchanged polls are not sent
+			Eventually(func() int {
+				lock.Lock()
+				defer lock.Unlock()
+				return len(queries)
+			}).Should(BeNumerically(">", 3))
+			setStatus("In Progress")
+
+			resp, err = stream.Recv()
+			Expect(err).To(BeNil())
+			Expect(resp.GetDates()[0].GetGames()[0].GetStatus().GetDetailedState()).To(Equal("In Progress"))
+		}, SpecTimeout(5*time.Second))
+
+		It("should return the error of an invalid request", func(ctx SpecContext) {
+			stream, err := client.WatchSchedule(ctx, &pb.WatchScheduleRequest{Request: &pb.GetScheduleRequest{Team: "147", Date: "bogus"}})
+			Expect(err).To(BeNil())
+			_, err = stream.Recv()
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+	})
+})
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+	"github.com/stefanKnott/mlbtakehome/pkg/store"
+)
+
+// BackfillOptions bounds the load a backfill places on statsapi
+type BackfillOptions struct {
+	// Concurrency is the number of dates fetched at once
+	Concurrency int
+	// Interval is the minimum time between upstream requests
+	Interval time.Duration
+	// Progress receives a line for every date backfilled
+	Progress io.Writer
+}
+
+// backfiller walks every date of a set of seasons into the schedule store
+type backfiller struct {
+	store  store.ScheduleStore
+	season func(year int) (models.Season, error)
+	fetch  func(date string) (*models.ScheduleResponse, error)
+	today  func() string
+	opts   BackfillOptions
+}
+
+// Backfill stores the schedule of every date of the given seasons, dates that
+// are already stored with every game final are skipped so that an interrupted
+// backfill resumes where it left off
+func Backfill(ctx context.Context, seasons []int, opts BackfillOptions) error {
+	if scheduleStore == nil {
+		return errors.New("backfill requires a schedule store")
+	}
+
+	b := &backfiller{
+		store:  scheduleStore,
+		season: getSeason,
+		fetch: func(date string) (*models.ScheduleResponse, error) {
+			return getScheduleAPIResp(fmt.Sprintf(scheuldeAPIFmtStr, date))
+		},
+		today: TodayDate,
+		opts:  opts,
+	}
+	return b.run(ctx, seasons)
+}
+
+// seasonDates lists every date from the start of spring training to the end
+// of the postseason, dates after today are left for a later backfill
+func (b *backfiller) seasonDates(year int) ([]string, error) {
+	season, err := b.season(year)
+	if err != nil {
+		return nil, err
+	}
+
+	start, err := time.Parse("2006-01-02", season.SeasonStartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date for %d: %s", year, season.SeasonStartDate)
+	}
+	end, err := time.Parse("2006-01-02", season.SeasonEndDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date for %d: %s", year, season.SeasonEndDate)
+	}
+	today, err := time.Parse("2006-01-02", b.today())
+	if err == nil && today.Before(end) {
+		end = today
+	}
+
+	dates := make([]string, 0)
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		dates = append(dates, d.Format("2006-01-02"))
+	}
+	return dates, nil
+}
+
+// backfilled reports whether a date is stored and will no longer change, a date
+// stored without games is an off day
+func (b *backfiller) backfilled(date string) (bool, error) {
+	d, ok, err := b.store.GetDate(date)
+	if err != nil || !ok {
+		return false, err
+	}
+	return len(d.Games) == 0 || allFinal(d.Games), nil
+}
+
+// backfillDate fetches a single date and stores it, recording off days so that
+// they are not fetched again
+func (b *backfiller) backfillDate(date string) (int, error) {
+	schedResp, err := b.fetch(date)
+	if err != nil {
+		return 0, err
+	}
+
+	games := 0
+	found := false
+	for _, d := range schedResp.Dates {
+		err = b.store.PutDate(d)
+		if err != nil {
+			return 0, err
+		}
+		if d.Date == date {
+			found = true
+			games = len(d.Games)
+		}
+	}
+	if !found {
+		err = b.store.PutDate(models.Date{Date: date, Games: make([]models.Game, 0)})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return games, nil
+}
+
+func (b *backfiller) run(ctx context.Context, seasons []int) error {
+	pending := make([]string, 0)
+	for _, year := range seasons {
+		dates, err := b.seasonDates(year)
+		if err != nil {
+			return err
+		}
+
+		skipped := 0
+		for _, date := range dates {
+			done, err := b.backfilled(date)
+			if err != nil {
+				return err
+			}
+			if done {
+				skipped++
+				continue
+			}
+			pending = append(pending, date)
+		}
+		b.progressf("season %d: %d dates, %d already backfilled\n", year, len(dates), skipped)
+	}
+
+	concurrency := b.opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	interval := b.opts.Interval
+	if interval <= 0 {
+		interval = time.Nanosecond
+	}
+	limiter := time.NewTicker(interval)
+	defer limiter.Stop()
+
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	completed, failed := 0, 0
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for date := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					continue
+				case <-limiter.C:
+				}
+
+				games, err := b.backfillDate(date)
+
+				lock.Lock()
+				completed++
+				if err != nil {
+					failed++
+					b.progressf("[%d/%d] %s: %s\n", completed, len(pending), date, err.Error())
+				} else {
+					b.progressf("[%d/%d] %s: %d games\n", completed, len(pending), date, games)
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+
+send:
+	for _, date := range pending {
+		select {
+		case <-ctx.Done():
+			break send
+		case jobs <- date:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return fmt.Errorf("backfill interrupted after %d of %d dates, run it again to resume", completed, len(pending))
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to backfill %d of %d dates, run it again to retry them", failed, len(pending))
+	}
+	return nil
+}
+
+func (b *backfiller) progressf(format string, a ...interface{}) {
+	if b.opts.Progress != nil {
+		fmt.Fprintf(b.opts.Progress, format, a...)
+	}
+}
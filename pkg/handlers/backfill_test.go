@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+	"github.com/stefanKnott/mlbtakehome/pkg/store"
+)
+
+var _ = Describe("Backfilling seasons", Label("Backfill"), func() {
+	var s *store.BoltStore
+	var b *backfiller
+	var lock sync.Mutex
+	var fetched []string
+	var inFlight, maxInFlight int
+
+	BeforeEach(func() {
+		var err error
+		s, err = store.NewBoltStore(filepath.Join(GinkgoT().TempDir(), "test.db"))
+		Expect(err).To(BeNil())
+
+		fetched = make([]string, 0)
+		inFlight, maxInFlight = 0, 0
+		b = &backfiller{
+			store: s,
+			season: func(year int) (models.Season, error) {
+				return models.Season{SeasonID: "2021", SeasonStartDate: "2021-09-10", SeasonEndDate: "2021-09-14"}, nil
+			},
+			fetch: func(date string) (*models.ScheduleResponse, error) {
+				lock.Lock()
+				fetched = append(fetched, date)
+				gamePk := len(fetched)
+				inFlight++
+				if inFlight > maxInFlight {
+					maxInFlight = inFlight
+				}
+				lock.Unlock()
+
+				time.Sleep(5 * time.Millisecond)
+
+				lock.Lock()
+				inFlight--
+				lock.Unlock()
+
+				if date == "2021-09-13" {
+					// off day
+					return &models.ScheduleResponse{Dates: make([]models.Date, 0)}, nil
+				}
+				return &models.ScheduleResponse{Dates: []models.Date{{
+					Date:  date,
+					Games: []models.Game{newTestGame(gamePk, date+"T23:05:00Z", 147, 111, "F", "Fenway Park")},
+				}}}, nil
+			},
+			today: func() string { return "2021-10-01" },
+			opts:  BackfillOptions{Concurrency: 2, Interval: time.Millisecond, Progress: new(bytes.Buffer)},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(s.Close()).To(Succeed())
+	})
+
+	When("We backfill a season", func() {
+		It("should store every date, including off days", func(ctx SpecContext) {
+			Expect(b.run(ctx, []int{2021})).To(Succeed())
+			Expect(fetched).To(ConsistOf("2021-09-10", "2021-09-11", "2021-09-12", "2021-09-13", "2021-09-14"))
+
+			d, ok, err := s.GetDate("2021-09-11")
+			Expect(err).To(BeNil())
+			Expect(ok).To(BeTrue())
+			Expect(d.Games).To(HaveLen(1))
+
+			d, ok, err = s.GetDate("2021-09-13")
+			Expect(err).To(BeNil())
+			Expect(ok).To(BeTrue())
+			Expect(d.Games).To(BeEmpty())
+		})
+
+		It("should not fetch more dates at once than allowed", func(ctx SpecContext) {
+			Expect(b.run(ctx, []int{2021})).To(Succeed())
+			Expect(maxInFlight).To(BeNumerically("<=", 2))
+		})
+	})
+
+	When("We backfill a season in progress", func() {
+		It("should stop at today", func(ctx SpecContext) {
+			b.today = func() string { return "2021-09-11" }
+			Expect(b.run(ctx, []int{2021})).To(Succeed())
+			Expect(fetched).To(ConsistOf("2021-09-10", "2021-09-11"))
+		})
+	})
+
+	When("We resume a backfill", func() {
+		It("should only fetch dates that are missing or not yet final", func(ctx SpecContext) {
+			Expect(s.PutDate(models.Date{Date: "2021-09-10", Games: []models.Game{newTestGame(1, "2021-09-10T23:05:00Z", 147, 111, "F", "Fenway Park")}})).To(Succeed())
+			Expect(s.PutDate(models.Date{Date: "2021-09-11", Games: []models.Game{newTestGame(2, "2021-09-11T23:05:00Z", 147, 111, "P", "Fenway Park")}})).To(Succeed())
+			Expect(s.PutDate(models.Date{Date: "2021-09-13", Games: make([]models.Game, 0)})).To(Succeed())
+
+			Expect(b.run(ctx, []int{2021})).To(Succeed())
+			Expect(fetched).To(ConsistOf("2021-09-11", "2021-09-12", "2021-09-14"))
+		})
+	})
+
+	When("A date fails to fetch", func() {
+		It("should report the failure and leave the date for the next run", func(ctx SpecContext) {
+			fetch := b.fetch
+			b.fetch = func(date string) (*models.ScheduleResponse, error) {
+				if date == "2021-09-12" {
+					return nil, errors.New("connection reset")
+				}
+				return fetch(date)
+			}
+			Expect(b.run(ctx, []int{2021})).NotTo(Succeed())
+
+			_, ok, err := s.GetDate("2021-09-12")
+			Expect(err).To(BeNil())
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	When("The backfill is interrupted", func() {
+		It("should stop fetching", func(ctx SpecContext) {
+			cancelled, cancel := context.WithCancel(ctx)
+			cancel()
+			Expect(b.run(cancelled, []int{2021})).NotTo(Succeed())
+			Expect(fetched).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+// how long shared caches may serve a schedule, by the state of its games
+const (
+	liveMaxAge   = 15 * time.Second
+	futureMaxAge = 5 * time.Minute
+	offDayMaxAge = time.Hour
+	finalMaxAge  = 24 * time.Hour
+)
+
+// scheduleMaxAge is the max-age of the game needing the freshest copy, live
+// games change pitch by pitch while final games do not change at all
+func scheduleMaxAge(games []models.Game) time.Duration {
+	if len(games) == 0 {
+		return offDayMaxAge
+	}
+	maxAge := finalMaxAge
+	for _, g := range games {
+		switch g.Status.AbstractGameCode {
+		case "L":
+			return liveMaxAge
+		case "P":
+			maxAge = futureMaxAge
+		}
+	}
+	return maxAge
+}
+
+// scheduleETag is a strong validator over the ordered response and the options
+// shaping its representation, so that a CSV or field selection of the same
+// schedule never shares a tag with the full JSON
+func scheduleETag(c *gin.Context, format string, schedResp *ScheduleResponse) (string, error) {
+	b, err := json.Marshal(schedResp)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write(b)
+	fmt.Fprintf(h, "\n%s\n%s\n%s", format, c.Query("fields"), c.Query("view"))
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`, nil
+}
+
+// matchesETag reports whether an If-None-Match header lists etag, which it
+// compares weakly as RFC 7232 requires
+func matchesETag(ifNoneMatch string, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// writeCacheHeaders sets the ETag, Last-Modified and Cache-Control headers of
+// a schedule response, answering with a 304 when the client's copy is current.
+// It reports whether the response has been written
+func writeCacheHeaders(c *gin.Context, format string, schedResp *ScheduleResponse) bool {
+	games := make([]models.Game, 0)
+	for _, d := range schedResp.Dates {
+		games = append(games, d.Games...)
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(scheduleMaxAge(games).Seconds())))
+	c.Header("Vary", "Accept")
+	// advisory only, the ETag is the authoritative validator
+	if lastModified, ok := snapshots.lastModified(games); ok {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	etag, err := scheduleETag(c, format, schedResp)
+	if err != nil {
+		return false
+	}
+	c.Header("ETag", etag)
+
+	if ifNoneMatch := c.GetHeader("If-None-Match"); ifNoneMatch != "" && matchesETag(ifNoneMatch, etag) {
+		c.Status(http.StatusNotModified)
+		return true
+	}
+	return false
+}
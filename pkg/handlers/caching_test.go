@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+var _ = Describe("Caching schedule responses", Label("Caching"), func() {
+	var router *gin.Engine
+	var games []models.Game
+
+	BeforeEach(func() {
+		snapshots = newScheduleSnapshots()
+		DeferCleanup(func() {
+			snapshots = newScheduleSnapshots()
+		})
+		games = []models.Game{
+			newTestGame(1, "2021-09-11T17:05:00Z", 147, 111, "F", "Fenway Park"),
+			newTestGame(2, "2021-09-11T23:05:00Z", 141, 110, "P", "Oriole Park at Camden Yards"),
+		}
+		router = newScheduleRouter(func() []models.Date {
+			return []models.Date{{Date: "2021-09-11", Games: games}}
+		})
+	})
+
+	get := func(url string, ifNoneMatch string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+	const url = "/schedule?teamId=147&date=2021-09-11"
+
+	When("The client's copy is current", func() {
+		It("should respond with a 304 and no body", func(ctx SpecContext) {
+			w := get(url, "")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			etag := w.Header().Get("ETag")
+			Expect(etag).To(MatchRegexp(`^"[0-9a-f]{32}"$`))
+
+			w = get(url, etag)
+			Expect(w.Code).To(Equal(http.StatusNotModified))
+			Expect(w.Body.Len()).To(Equal(0))
+			Expect(w.Header().Get("ETag")).To(Equal(etag))
+			Expect(w.Header().Get("Cache-Control")).NotTo(BeEmpty())
+
+			Expect(get(url, `"stale", W/`+etag).Code).To(Equal(http.StatusNotModified))
+			Expect(get(url, "*").Code).To(Equal(http.StatusNotModified))
+		})
+	})
+
+	When("The client's copy of a field selection or export is current", func() {
+		It("should respond with a 304 and no body", func(ctx SpecContext) {
+			for _, query := range []string{"&fields=gamePk", "&view=compact", "&format=csv", "&format=ndjson"} {
+				w := get(url+query, "")
+				Expect(w.Code).To(Equal(http.StatusOK))
+				etag := w.Header().Get("ETag")
+				Expect(etag).NotTo(BeEmpty())
+
+				w = get(url+query, etag)
+				Expect(w.Code).To(Equal(http.StatusNotModified))
+				Expect(w.Body.Len()).To(Equal(0))
+			}
+		})
+	})
+
+	When("The schedule changes", func() {
+		It("should change the ETag", func(ctx SpecContext) {
+			etag := get(url, "").Header().Get("ETag")
+			games[1].Status.AbstractGameCode = "L"
+			w := get(url, etag)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Header().Get("ETag")).NotTo(Equal(etag))
+		})
+
+		It("should change the ETag when the order changes", func(ctx SpecContext) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, url, nil)
+			schedResp := &ScheduleResponse{ScheduleResponse: models.ScheduleResponse{Dates: []models.Date{{Date: "2021-09-11", Games: games}}}}
+			etag, err := scheduleETag(c, formatJSON, schedResp)
+			Expect(err).To(BeNil())
+
+			schedResp.Dates[0].Games = []models.Game{games[1], games[0]}
+			reordered, err := scheduleETag(c, formatJSON, schedResp)
+			Expect(err).To(BeNil())
+			Expect(reordered).NotTo(Equal(etag))
+		})
+	})
+
+	When("The same schedule is represented differently", func() {
+		It("should not share an ETag", func(ctx SpecContext) {
+			etags := map[string]bool{}
+			for _, query := range []string{"", "&format=csv", "&view=compact", "&fields=gamePk"} {
+				etags[get(url+query, "").Header().Get("ETag")] = true
+			}
+			Expect(etags).To(HaveLen(4))
+		})
+	})
+
+	When("We choose a max-age", func() {
+		It("should follow the state of the games", func(ctx SpecContext) {
+			final := newTestGame(1, "2021-09-11T17:05:00Z", 147, 111, "F", "Fenway Park")
+			live := newTestGame(2, "2021-09-11T20:05:00Z", 141, 110, "L", "Oriole Park at Camden Yards")
+			future := newTestGame(3, "2021-09-11T23:05:00Z", 121, 143, "P", "Citizens Bank Park")
+
+			Expect(scheduleMaxAge([]models.Game{final})).To(Equal(finalMaxAge))
+			Expect(scheduleMaxAge([]models.Game{final, future})).To(Equal(futureMaxAge))
+			Expect(scheduleMaxAge([]models.Game{final, live, future})).To(Equal(liveMaxAge))
+			Expect(scheduleMaxAge([]models.Game{})).To(Equal(offDayMaxAge))
+
+			Expect(get(url, "").Header().Get("Cache-Control")).To(Equal("public, max-age=300"))
+		})
+	})
+
+	When("We have seen the games change", func() {
+		It("should set Last-Modified to when the newest status change was seen", func(ctx SpecContext) {
+			// snapshots are only kept for dates near today
+			first := time.Now().UTC().Add(-4 * time.Hour).Truncate(time.Second)
+			today := first.Format("2006-01-02")
+			url := "/schedule?teamId=147&date=" + today
+			snapshots.record(today, games, first)
+
+			live := make([]models.Game, len(games))
+			copy(live, games)
+			live[1].Status.AbstractGameCode = "L"
+			live[1].Status.DetailedState = "In Progress"
+			snapshots.record(today, live, first.Add(time.Hour))
+			// an unchanged status keeps the time it changed
+			snapshots.record(today, live, first.Add(2*time.Hour))
+
+			games = live
+			Expect(get(url, "").Header().Get("Last-Modified")).To(Equal(first.Add(time.Hour).Format(http.TimeFormat)))
+
+			// a score change is not a status change
+			scored := make([]models.Game, len(live))
+			copy(scored, live)
+			scored[1].Teams.Home.Score = 1
+			snapshots.record(today, scored, first.Add(3*time.Hour))
+
+			games = scored
+			Expect(get(url, "").Header().Get("Last-Modified")).To(Equal(first.Add(time.Hour).Format(http.TimeFormat)))
+
+			final := make([]models.Game, len(scored))
+			copy(final, scored)
+			final[1].Status.AbstractGameCode = "F"
+			final[1].Status.DetailedState = "Final"
+			snapshots.record(today, final, first.Add(4*time.Hour))
+
+			games = final
+			Expect(get(url, "").Header().Get("Last-Modified")).To(Equal(first.Add(4 * time.Hour).Format(http.TimeFormat)))
+		})
+
+		It("should not set Last-Modified for dates it has not snapshotted", func(ctx SpecContext) {
+			Expect(get(url, "").Header().Get("Last-Modified")).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,317 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+const (
+	// maxGameChanges bounds the change feed, the oldest changes are dropped first
+	maxGameChanges = 10000
+	changePollRate = 5 * time.Minute
+	// liveChangePollRate is used instead while a polled game is live or about to start
+	liveChangePollRate = 30 * time.Second
+	// snapshotWindow bounds the dates snapshotted to those this close to the
+	// current date, the schedule of dates further away rarely changes
+	snapshotWindow = 30 * 24 * time.Hour
+)
+
+// structs for /schedule/changes API responses
+type ScheduleChangesResponse struct {
+	Since   string       `json:"since"`
+	Changes []GameChange `json:"changes"`
+}
+
+type GameChange struct {
+	GamePk     int           `json:"gamePk"`
+	Date       string        `json:"date"`
+	DetectedAt string        `json:"detectedAt"`
+	Changes    []FieldChange `json:"changes"`
+
+	detectedAt time.Time
+	before     models.Game
+	after      models.Game
+}
+
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// scheduleSnapshots holds the last schedule seen for each date and the
+// changes detected between successive snapshots
+type scheduleSnapshots struct {
+	lock    sync.RWMutex
+	dates   map[string]*dateSnapshot
+	changes []GameChange
+	// the last version of each game seen on any date, so that a game moved to
+	// another date is detected as a start time change rather than a new game
+	seen map[int]seenGame
+}
+
+type seenGame struct {
+	date string
+	game models.Game
+}
+
+// dateSnapshot is the last schedule seen for a date
+type dateSnapshot struct {
+	games map[int]models.Game
+	// when the fetch the snapshot was taken from started
+	fetchedAt time.Time
+	// when each game's status was last seen to change, or the game was first seen
+	statusChangedAt map[int]time.Time
+}
+
+var snapshots = newScheduleSnapshots()
+
+func newScheduleSnapshots() *scheduleSnapshots {
+	return &scheduleSnapshots{dates: make(map[string]*dateSnapshot), seen: make(map[int]seenGame)}
+}
+
+// inSnapshotWindow reports whether date is close enough to now to be snapshotted
+func inSnapshotWindow(date string, now time.Time) bool {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return false
+	}
+	diff := day.Sub(now)
+	return diff < snapshotWindow && diff > -snapshotWindow
+}
+
+// diffGame lists the tracked fields that differ between two versions of a game
+func diffGame(before models.Game, after models.Game) []FieldChange {
+	changes := make([]FieldChange, 0)
+	if before.GameDate != after.GameDate {
+		changes = append(changes, FieldChange{Field: "gameDate", Before: before.GameDate, After: after.GameDate})
+	}
+	if before.Status.DetailedState != after.Status.DetailedState {
+		changes = append(changes, FieldChange{Field: "status", Before: before.Status.DetailedState, After: after.Status.DetailedState})
+	}
+	if before.DoubleHeader != after.DoubleHeader {
+		changes = append(changes, FieldChange{Field: "doubleHeader", Before: before.DoubleHeader, After: after.DoubleHeader})
+	}
+	if before.Venue.ID != after.Venue.ID {
+		changes = append(changes, FieldChange{Field: "venue", Before: before.Venue.Name, After: after.Venue.Name})
+	}
+	return changes
+}
+
+// record snapshots the games scheduled on date as fetched at now, returning
+// the changes detected against the previous snapshot of that date, or the
+// snapshot of the date a game was moved from. Dates outside the snapshot
+// window are dropped, as is a fetch that started before the one the current
+// snapshot was taken from, it would appear to revert changes
+func (s *scheduleSnapshots) record(date string, games []models.Game, now time.Time) []GameChange {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for d := range s.dates {
+		if !inSnapshotWindow(d, now) {
+			delete(s.dates, d)
+		}
+	}
+	for gamePk, seen := range s.seen {
+		if !inSnapshotWindow(seen.date, now) {
+			delete(s.seen, gamePk)
+		}
+	}
+	if !inSnapshotWindow(date, now) {
+		return nil
+	}
+
+	last, ok := s.dates[date]
+	if ok && !now.After(last.fetchedAt) {
+		return nil
+	}
+
+	snapshot := &dateSnapshot{
+		games:           make(map[int]models.Game, len(games)),
+		fetchedAt:       now,
+		statusChangedAt: make(map[int]time.Time, len(games)),
+	}
+	var previous map[int]models.Game
+	if ok {
+		previous = last.games
+	}
+	moved := make(map[int]models.Game)
+	for _, g := range games {
+		snapshot.games[g.GamePk] = g
+		snapshot.statusChangedAt[g.GamePk] = now
+		before, listed := previous[g.GamePk]
+		if listed && before.Status.AbstractGameCode == g.Status.AbstractGameCode && before.Status.DetailedState == g.Status.DetailedState {
+			snapshot.statusChangedAt[g.GamePk] = last.statusChangedAt[g.GamePk]
+		}
+		if !listed {
+			if seen, found := s.seen[g.GamePk]; found && seen.date != date {
+				moved[g.GamePk] = seen.game
+			}
+		}
+		s.seen[g.GamePk] = seenGame{date: date, game: g}
+	}
+	s.dates[date] = snapshot
+
+	detected := make([]GameChange, 0)
+	newChange := func(gamePk int, before models.Game, after models.Game, changes []FieldChange) GameChange {
+		return GameChange{
+			GamePk:     gamePk,
+			Date:       date,
+			DetectedAt: now.UTC().Format(time.RFC3339Nano),
+			Changes:    changes,
+			detectedAt: now,
+			before:     before,
+			after:      after,
+		}
+	}
+	for _, g := range games {
+		before, listed := previous[g.GamePk]
+		if !listed {
+			before, listed = moved[g.GamePk]
+		}
+		if !listed {
+			// nothing to compare against the first time a date is seen
+			if ok {
+				detected = append(detected, newChange(g.GamePk, models.Game{}, g, []FieldChange{{Field: "game", After: "scheduled"}}))
+			}
+			continue
+		}
+		if changes := diffGame(before, g); len(changes) > 0 {
+			detected = append(detected, newChange(g.GamePk, before, g, changes))
+		}
+	}
+	// previous is nil the first time a date is seen
+	for gamePk, before := range previous {
+		if _, ok := snapshot.games[gamePk]; !ok {
+			detected = append(detected, newChange(gamePk, before, models.Game{}, []FieldChange{{Field: "game", Before: "scheduled", After: "removed"}}))
+		}
+	}
+
+	s.changes = append(s.changes, detected...)
+	if len(s.changes) > maxGameChanges {
+		s.changes = s.changes[len(s.changes)-maxGameChanges:]
+	}
+	return detected
+}
+
+// since returns the changes detected after t, oldest first
+func (s *scheduleSnapshots) since(t time.Time) []GameChange {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	changes := make([]GameChange, 0)
+	for _, change := range s.changes {
+		if change.detectedAt.After(t) {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// lastModified returns when the newest status change of the games was seen,
+// false when none of the games have been snapshotted. A score change alone does
+// not move it forward, and a game listed on more than one date, ie. a suspended
+// game, takes the newest change seen on any of them
+func (s *scheduleSnapshots) lastModified(games []models.Game) (time.Time, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var last time.Time
+	for _, snapshot := range s.dates {
+		for _, g := range games {
+			if changedAt, ok := snapshot.statusChangedAt[g.GamePk]; ok && changedAt.After(last) {
+				last = changedAt
+			}
+		}
+	}
+	return last, !last.IsZero()
+}
+
+// getRecordedScheduleAPIResp fetches complete dates from the schedule API,
+// snapshots them for the change feed and persists them, partial dates such as
+// a single team's schedule must not be recorded as they would appear to drop games
+func getRecordedScheduleAPIResp(url string) (*models.ScheduleResponse, error) {
+	// snapshots are ordered by when their fetch started, so that a slow fetch
+	// finishing after a later one is not taken for the newer schedule
+	fetchedAt := time.Now()
+	schedResp, err := getScheduleAPIResp(url)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range schedResp.Dates {
+		changes := snapshots.record(d.Date, d.Games, fetchedAt)
+		notifySubscribers(changes)
+	}
+	persistSchedule(schedResp)
+	return schedResp, nil
+}
+
+// changePollInterval is how long to wait before polling games again, state
+// transitions are only raised as webhook events once detected so games that
+// are live, or due to start, are polled more often
+func changePollInterval(games []models.Game, now time.Time) time.Duration {
+	for _, g := range games {
+		if g.Status.AbstractGameState == "Live" {
+			return liveChangePollRate
+		}
+		if g.Status.AbstractGameState != "Preview" {
+			continue
+		}
+		// a game up to half a day past its start time that is not live yet is delayed
+		start, err := time.Parse(time.RFC3339, g.GameDate)
+		if until := start.Sub(now); err == nil && until < changePollRate && until > -12*time.Hour {
+			return liveChangePollRate
+		}
+	}
+	return changePollRate
+}
+
+// InitChangeFeed polls the schedule around the current date so that changes
+// are detected even when no client requests those dates
+func InitChangeFeed() {
+	go func() {
+		for {
+			now := time.Now().UTC()
+			rate := changePollRate
+			for _, date := range []time.Time{now.AddDate(0, 0, -1), now, now.AddDate(0, 0, 1)} {
+				schedResp, err := getRecordedScheduleAPIResp(fmt.Sprintf(scheuldeAPIFmtStr, date.Format("2006-01-02")))
+				if err != nil {
+					fmt.Printf("got err when polling schedule API: %s\n", err.Error())
+					continue
+				}
+				for _, d := range schedResp.Dates {
+					if r := changePollInterval(d.Games, now); r < rate {
+						rate = r
+					}
+				}
+			}
+			time.Sleep(rate)
+		}
+	}()
+}
+
+// GetScheduleChanges serves the /schedule/changes?since=<RFC3339 timestamp> API
+// which allows a client to receive the games whose start time, status, double
+// header or venue changed after a point in time
+func GetScheduleChanges(c *gin.Context) {
+	since := c.Query("since")
+	t, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		// also accept unix timestamps
+		seconds, convErr := strconv.ParseInt(since, 10, 64)
+		if convErr != nil {
+			c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: "invalid since timestamp", Timestamp: time.Now().UTC().String()})
+			return
+		}
+		t = time.Unix(seconds, 0)
+	}
+
+	c.JSON(http.StatusOK, ScheduleChangesResponse{Since: t.UTC().Format(time.RFC3339), Changes: snapshots.since(t)})
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+var _ = Describe("Detecting schedule changes", Label("Changes"), func() {
+	var s *scheduleSnapshots
+	var first, second time.Time
+
+	BeforeEach(func() {
+		s = newScheduleSnapshots()
+		first = time.Date(2021, 9, 11, 12, 0, 0, 0, time.UTC)
+		second = first.Add(5 * time.Minute)
+	})
+
+	When("We snapshot a date for the first time", func() {
+		It("should not detect any changes", func(ctx SpecContext) {
+			games := []models.Game{newTestGame(1, "2021-09-11T23:05:00Z", 147, 111, "P", "Fenway Park")}
+			Expect(s.record("2021-09-11", games, first)).To(BeEmpty())
+			Expect(s.since(first.Add(-time.Minute))).To(BeEmpty())
+		})
+	})
+
+	When("A game is delayed and moved", func() {
+		It("should list the before and after values of each changed field", func(ctx SpecContext) {
+			game := newTestGame(1, "2021-09-11T23:05:00Z", 147, 111, "P", "Fenway Park")
+			game.Status.DetailedState = "Scheduled"
+			s.record("2021-09-11", []models.Game{game}, first)
+
+			game.GameDate = "2021-09-11T23:35:00Z"
+			game.Status.DetailedState = "Delayed Start"
+			changes := s.record("2021-09-11", []models.Game{game}, second)
+			Expect(changes).To(HaveLen(1))
+			Expect(changes[0].GamePk).To(Equal(1))
+			Expect(changes[0].Changes).To(Equal([]FieldChange{
+				{Field: "gameDate", Before: "2021-09-11T23:05:00Z", After: "2021-09-11T23:35:00Z"},
+				{Field: "status", Before: "Scheduled", After: "Delayed Start"},
+			}))
+		})
+	})
+
+	When("A game is moved to another date", func() {
+		It("should detect the start time change on the date it was moved to", func(ctx SpecContext) {
+			game := newTestGame(1, "2021-09-11T23:05:00Z", 147, 111, "P", "Fenway Park")
+			game.Status.AbstractGameState = "Preview"
+			s.record("2021-09-11", []models.Game{game}, first)
+
+			moved := game
+			moved.GameDate = "2021-09-12T17:05:00Z"
+			changes := s.record("2021-09-12", []models.Game{moved}, second)
+			Expect(changes).To(HaveLen(1))
+			Expect(changes[0].Date).To(Equal("2021-09-12"))
+			Expect(changes[0].Changes).To(Equal([]FieldChange{
+				{Field: "gameDate", Before: "2021-09-11T23:05:00Z", After: "2021-09-12T17:05:00Z"},
+			}))
+			Expect(gameEvents(changes[0])).To(Equal([]string{eventStartTimeChanged}))
+
+			// a game first seen on a date is not a change
+			other := newTestGame(2, "2021-09-12T23:05:00Z", 141, 110, "P", "Oriole Park at Camden Yards")
+			Expect(s.record("2021-09-13", []models.Game{other}, second)).To(BeEmpty())
+		})
+	})
+
+	When("A double header is scheduled", func() {
+		It("should detect the new game and the double header flag", func(ctx SpecContext) {
+			game1 := newTestGame(1, "2021-09-11T17:05:00Z", 147, 111, "P", "Fenway Park")
+			game1.DoubleHeader = "N"
+			s.record("2021-09-11", []models.Game{game1}, first)
+
+			game1.DoubleHeader = "Y"
+			game2 := newTestGame(2, "2021-09-11T23:05:00Z", 147, 111, "P", "Fenway Park")
+			game2.DoubleHeader = "Y"
+			changes := s.record("2021-09-11", []models.Game{game1, game2}, second)
+			Expect(changes).To(HaveLen(2))
+			Expect(changes[0].Changes[0].Field).To(Equal("doubleHeader"))
+			Expect(changes[1].Changes[0]).To(Equal(FieldChange{Field: "game", After: "scheduled"}))
+		})
+	})
+
+	When("An older fetch finishes after a newer one", func() {
+		It("should not detect the older schedule as a change", func(ctx SpecContext) {
+			game := newTestGame(1, "2021-09-11T23:05:00Z", 147, 111, "P", "Fenway Park")
+			game.Status.DetailedState = "Scheduled"
+			s.record("2021-09-11", []models.Game{game}, first)
+
+			delayed := game
+			delayed.Status.DetailedState = "Delayed Start"
+			Expect(s.record("2021-09-11", []models.Game{delayed}, second)).To(HaveLen(1))
+			Expect(s.record("2021-09-11", []models.Game{game}, first.Add(time.Minute))).To(BeEmpty())
+
+			// the next fetch is compared against the newest schedule
+			Expect(s.record("2021-09-11", []models.Game{delayed}, second.Add(time.Minute))).To(BeEmpty())
+		})
+	})
+
+	When("Dates fall out of the snapshot window", func() {
+		It("should evict their snapshots", func(ctx SpecContext) {
+			game := newTestGame(1, "2021-09-11T23:05:00Z", 147, 111, "P", "Fenway Park")
+			s.record("2021-09-11", []models.Game{game}, first)
+			Expect(s.dates).To(HaveKey("2021-09-11"))
+
+			// dates far from the current date are not snapshotted at all
+			Expect(s.record("2019-09-11", []models.Game{game}, first)).To(BeEmpty())
+			Expect(s.dates).NotTo(HaveKey("2019-09-11"))
+
+			later := first.Add(snapshotWindow + 24*time.Hour)
+			s.record(later.Format("2006-01-02"), []models.Game{game}, later)
+			Expect(s.dates).To(HaveLen(1))
+			Expect(s.dates).NotTo(HaveKey("2021-09-11"))
+		})
+	})
+
+	When("We decide how often to poll", func() {
+		It("should poll more often while a game is live or about to start", func(ctx SpecContext) {
+			game := newTestGame(1, "2021-09-11T23:05:00Z", 147, 111, "P", "Fenway Park")
+			game.Status.AbstractGameState = "Preview"
+			Expect(changePollInterval([]models.Game{game}, first)).To(Equal(changePollRate))
+
+			start, _ := time.Parse(time.RFC3339, game.GameDate)
+			Expect(changePollInterval([]models.Game{game}, start.Add(-time.Minute))).To(Equal(liveChangePollRate))
+			// delayed
+			Expect(changePollInterval([]models.Game{game}, start.Add(time.Hour))).To(Equal(liveChangePollRate))
+
+			game.Status.AbstractGameState = "Live"
+			Expect(changePollInterval([]models.Game{game}, first)).To(Equal(liveChangePollRate))
+			game.Status.AbstractGameState = "Final"
+			Expect(changePollInterval([]models.Game{game}, start.Add(time.Hour))).To(Equal(changePollRate))
+		})
+	})
+
+	When("We list changes since a point in time", func() {
+		It("should only return changes detected after it", func(ctx SpecContext) {
+			game := newTestGame(1, "2021-09-11T23:05:00Z", 147, 111, "P", "Fenway Park")
+			s.record("2021-09-11", []models.Game{game}, first)
+			game.Venue = models.Venue{ID: 5445, Name: "Field of Dreams"}
+			s.record("2021-09-11", []models.Game{game}, second)
+
+			Expect(s.since(first)).To(HaveLen(1))
+			Expect(s.since(second)).To(BeEmpty())
+		})
+	})
+})
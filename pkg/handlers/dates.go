@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+// clock returns the current time, tests replace it to pin today's date
+var clock = time.Now
+
+// relativeDateRegexp matches a number of days before or after today, ie. +3d or -1d.
+// A + left unencoded in a query string decodes to a space, so ?date=+3d reads " 3d"
+var relativeDateRegexp = regexp.MustCompile(`^\s*([+ -])(\d+)d$`)
+
+// seasonKeywordRegexp matches a season calendar keyword, optionally followed by a season, ie. opening-day-2019
+var seasonKeywordRegexp = regexp.MustCompile(`^([a-z-]+?)(?:-(\d{4}))?$`)
+
+// seasonKeywords resolve a keyword to a date on a season's calendar
+var seasonKeywords = map[string]func(models.Season) string{
+	"opening-day":    func(s models.Season) string { return s.RegularSeasonStartDate },
+	"all-star-break": func(s models.Season) string { return s.AllStarDate },
+	"all-star-game":  func(s models.Season) string { return s.AllStarDate },
+	"last-day":       func(s models.Season) string { return s.RegularSeasonEndDate },
+	"postseason":     func(s models.Season) string { return s.PostSeasonStartDate },
+}
+
+// easternTime returns US eastern time, which statsapi's official dates follow
+func easternTime() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// TodayDate returns today's date in US eastern time
+func TodayDate() string {
+	return clock().In(easternTime()).Format("2006-01-02")
+}
+
+// resolveDate resolves a YYYY-MM-DD date, a date relative to today (today,
+// yesterday, tomorrow, +3d, -1d) or a season calendar keyword (opening-day,
+// all-star-break, last-day, postseason, optionally suffixed with a season such
+// as opening-day-2019) to a canonical YYYY-MM-DD date
+func resolveDate(param string) (string, error) {
+	_, err := time.Parse("2006-01-02", param)
+	if err == nil {
+		return param, nil
+	}
+
+	relative := strings.ToLower(strings.TrimRightFunc(param, unicode.IsSpace))
+	param = strings.ToLower(strings.TrimSpace(param))
+	today := clock().In(easternTime())
+	switch param {
+	case "today":
+		return today.Format("2006-01-02"), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1).Format("2006-01-02"), nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1).Format("2006-01-02"), nil
+	}
+
+	if m := relativeDateRegexp.FindStringSubmatch(relative); m != nil {
+		days, err := strconv.Atoi(m[2])
+		if err != nil {
+			return "", errors.New("invalid date string")
+		}
+		if m[1] == "-" {
+			days = -days
+		}
+		return today.AddDate(0, 0, days).Format("2006-01-02"), nil
+	}
+
+	if m := seasonKeywordRegexp.FindStringSubmatch(param); m != nil {
+		keyword, ok := seasonKeywords[m[1]]
+		if !ok {
+			return "", errors.New("invalid date string")
+		}
+
+		year := today.Year()
+		if m[2] != "" {
+			year, _ = strconv.Atoi(m[2])
+		}
+		season, err := getSeason(year)
+		if err != nil {
+			return "", fmt.Errorf("could not resolve %s: %s", param, err.Error())
+		}
+
+		date := keyword(season)
+		_, err = time.Parse("2006-01-02", date)
+		if err != nil {
+			return "", fmt.Errorf("%s is not on the %d season calendar", m[1], year)
+		}
+		return date, nil
+	}
+
+	return "", errors.New("invalid date string")
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+var _ = Describe("Resolving dates", Label("Dates"), func() {
+	BeforeEach(func() {
+		// 1:30 AM UTC is still the previous evening in US eastern time
+		clock = func() time.Time { return time.Date(2021, 9, 12, 1, 30, 0, 0, time.UTC) }
+
+		seasonCache.lock.Lock()
+		seasonCache.seasons[2021] = models.Season{
+			SeasonID:               "2021",
+			RegularSeasonStartDate: "2021-04-01",
+			AllStarDate:            "2021-07-13",
+			RegularSeasonEndDate:   "2021-10-03",
+			PostSeasonStartDate:    "2021-10-05",
+		}
+		seasonCache.seasons[2019] = models.Season{SeasonID: "2019", RegularSeasonStartDate: "2019-03-20"}
+		seasonCache.lock.Unlock()
+	})
+
+	AfterEach(func() {
+		clock = time.Now
+		seasonCache.lock.Lock()
+		seasonCache.seasons = make(map[int]models.Season)
+		seasonCache.lock.Unlock()
+	})
+
+	When("We request a YYYY-MM-DD date", func() {
+		It("should be returned as is", func(ctx SpecContext) {
+			Expect(resolveDate("2021-04-01")).To(Equal("2021-04-01"))
+		})
+	})
+
+	When("We request a date relative to today", func() {
+		It("should resolve against today in US eastern time", func(ctx SpecContext) {
+			Expect(resolveDate("today")).To(Equal("2021-09-11"))
+			Expect(resolveDate("Yesterday")).To(Equal("2021-09-10"))
+			Expect(resolveDate("tomorrow")).To(Equal("2021-09-12"))
+			Expect(resolveDate("+3d")).To(Equal("2021-09-14"))
+			Expect(resolveDate("-30d")).To(Equal("2021-08-12"))
+			// +3d in a query string decodes to " 3d"
+			Expect(resolveDate(" 3d")).To(Equal("2021-09-14"))
+		})
+
+		It("should resolve a date sent unencoded in a /schedule query string", func(ctx SpecContext) {
+			var teamResp models.TeamsResponse
+			setLock = new(sync.RWMutex)
+			Expect(json.Unmarshal([]byte(teamsAPIJSON), &teamResp)).To(Succeed())
+			createTeamsSet(teamResp)
+			getScheduleAPIResp = func(url string) (*models.ScheduleResponse, error) {
+				return &models.ScheduleResponse{Dates: []models.Date{{Date: "2021-09-14", Games: []models.Game{newTestGame(1, "2021-09-14T23:05:00Z", 147, 111, "P", "Fenway Park")}}}}, nil
+			}
+			DeferCleanup(func() {
+				getScheduleAPIResp = fetchScheduleAPIResp
+			})
+
+			router := gin.New()
+			router.GET("/schedule", GetSchedule)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/schedule?teamId=147&date=+3d", nil))
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+
+			var resp ScheduleResponse
+			Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+			Expect(resp.Date).To(Equal("2021-09-14"))
+		})
+	})
+
+	When("We request a season keyword", func() {
+		It("should resolve against the current season's calendar", func(ctx SpecContext) {
+			Expect(resolveDate("opening-day")).To(Equal("2021-04-01"))
+			Expect(resolveDate("all-star-break")).To(Equal("2021-07-13"))
+			Expect(resolveDate("last-day")).To(Equal("2021-10-03"))
+			Expect(resolveDate("postseason")).To(Equal("2021-10-05"))
+		})
+
+		It("should resolve against the given season's calendar", func(ctx SpecContext) {
+			Expect(resolveDate("opening-day-2019")).To(Equal("2019-03-20"))
+		})
+
+		It("should fail when the season has no such date", func(ctx SpecContext) {
+			_, err := resolveDate("all-star-break-2019")
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	When("We request an unrecognized date", func() {
+		It("should return an error", func(ctx SpecContext) {
+			for _, param := range []string{"", "someday", "+3", "3d", "2021-04-q01"} {
+				_, err := resolveDate(param)
+				Expect(err).To(MatchError("invalid date string"))
+			}
+		})
+	})
+})
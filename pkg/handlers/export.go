@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+const (
+	formatJSON   = "json"
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+
+	csvContentType    = "text/csv; charset=utf-8"
+	ndjsonContentType = "application/x-ndjson"
+
+	// rows written between flushes to the client
+	exportFlushRows = 100
+)
+
+// exportColumns is the CSV header, in the order of ExportGame's fields
+var exportColumns = []string{
+	"gamePk",
+	"date",
+	"gameDate",
+	"gameType",
+	"awayId",
+	"away",
+	"awayScore",
+	"homeId",
+	"home",
+	"homeScore",
+	"status",
+	"venue",
+	"doubleHeader",
+	"gameNumber",
+}
+
+// ExportGame is a game flattened into the columns of a CSV or NDJSON export,
+// scores are omitted for games that have not started
+type ExportGame struct {
+	GamePk       int    `json:"gamePk"`
+	Date         string `json:"date"`
+	GameDate     string `json:"gameDate"`
+	GameType     string `json:"gameType"`
+	AwayID       int    `json:"awayId"`
+	Away         string `json:"away"`
+	AwayScore    *uint8 `json:"awayScore"`
+	HomeID       int    `json:"homeId"`
+	Home         string `json:"home"`
+	HomeScore    *uint8 `json:"homeScore"`
+	Status       string `json:"status"`
+	Venue        string `json:"venue"`
+	DoubleHeader string `json:"doubleHeader"`
+	GameNumber   uint8  `json:"gameNumber"`
+}
+
+func newExportGame(date string, g models.Game) ExportGame {
+	e := ExportGame{
+		GamePk:       g.GamePk,
+		Date:         date,
+		GameDate:     g.GameDate,
+		GameType:     g.GameType,
+		AwayID:       g.Teams.Away.Team.ID,
+		Away:         g.Teams.Away.Team.Name,
+		HomeID:       g.Teams.Home.Team.ID,
+		Home:         g.Teams.Home.Team.Name,
+		Status:       g.Status.DetailedState,
+		Venue:        g.Venue.Name,
+		DoubleHeader: g.DoubleHeader,
+		GameNumber:   g.GameNumber,
+	}
+	if g.Status.AbstractGameCode != "P" {
+		awayScore, homeScore := g.Teams.Away.Score, g.Teams.Home.Score
+		e.AwayScore, e.HomeScore = &awayScore, &homeScore
+	}
+	return e
+}
+
+func formatScore(score *uint8) string {
+	if score == nil {
+		return ""
+	}
+	return strconv.Itoa(int(*score))
+}
+
+func (e ExportGame) record() []string {
+	return []string{
+		strconv.Itoa(e.GamePk),
+		e.Date,
+		e.GameDate,
+		e.GameType,
+		strconv.Itoa(e.AwayID),
+		e.Away,
+		formatScore(e.AwayScore),
+		strconv.Itoa(e.HomeID),
+		e.Home,
+		formatScore(e.HomeScore),
+		e.Status,
+		e.Venue,
+		e.DoubleHeader,
+		strconv.Itoa(int(e.GameNumber)),
+	}
+}
+
+// negotiateFormat picks the response format from the format=<json|csv|ndjson>
+// query parameter, falling back to the Accept header and then JSON
+func negotiateFormat(c *gin.Context) (string, error) {
+	if format, ok := c.GetQuery("format"); ok {
+		switch format {
+		case formatJSON, formatCSV, formatNDJSON:
+			return format, nil
+		}
+		return "", fmt.Errorf("invalid format: %s", format)
+	}
+
+	accept := c.GetHeader("Accept")
+	switch {
+	case strings.Contains(accept, "text/csv"):
+		return formatCSV, nil
+	case strings.Contains(accept, "application/x-ndjson"), strings.Contains(accept, "application/ndjson"):
+		return formatNDJSON, nil
+	}
+	return formatJSON, nil
+}
+
+// writeGames streams the games of each date as CSV rows or NDJSON lines,
+// flushing as it goes so that a full season is never held in the response buffer
+func writeGames(c *gin.Context, format string, dates []models.Date) {
+	var write func(ExportGame) error
+	flush := func() error { return nil }
+	switch format {
+	case formatCSV:
+		c.Header("Content-Type", csvContentType)
+		c.Status(http.StatusOK)
+		w := csv.NewWriter(c.Writer)
+		w.Write(exportColumns)
+		write = func(e ExportGame) error { return w.Write(e.record()) }
+		// the csv writer buffers rows, its write errors surface on flush
+		flush = func() error {
+			w.Flush()
+			return w.Error()
+		}
+	case formatNDJSON:
+		c.Header("Content-Type", ndjsonContentType)
+		c.Status(http.StatusOK)
+		encoder := json.NewEncoder(c.Writer)
+		write = func(e ExportGame) error { return encoder.Encode(e) }
+	default:
+		return
+	}
+
+	rows := 0
+	for _, d := range dates {
+		for _, g := range d.Games {
+			err := write(newExportGame(d.Date, g))
+			if err != nil {
+				// the client has gone away
+				return
+			}
+			rows++
+			if rows%exportFlushRows == 0 {
+				if flush() != nil {
+					return
+				}
+				c.Writer.Flush()
+			}
+		}
+	}
+	if flush() != nil {
+		return
+	}
+	c.Writer.Flush()
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+// newScheduleRouter routes /schedule to GetSchedule as the server does, with
+// statsapi replaced by the dates returned by dates
+func newScheduleRouter(dates func() []models.Date) *gin.Engine {
+	var teamResp models.TeamsResponse
+	setLock = new(sync.RWMutex)
+	Expect(json.Unmarshal([]byte(teamsAPIJSON), &teamResp)).To(Succeed())
+	createTeamsSet(teamResp)
+
+	seasonCache.lock.Lock()
+	for _, year := range []int{2021, time.Now().Year()} {
+		seasonCache.seasons[year] = models.Season{SeasonID: strconv.Itoa(year), SeasonStartDate: fmt.Sprintf("%d-01-01", year), SeasonEndDate: fmt.Sprintf("%d-12-31", year)}
+	}
+	seasonCache.lock.Unlock()
+
+	getScheduleAPIResp = func(url string) (*models.ScheduleResponse, error) {
+		schedResp := &models.ScheduleResponse{Dates: make([]models.Date, 0)}
+		for _, d := range dates() {
+			d.Games = append([]models.Game{}, d.Games...)
+			schedResp.Dates = append(schedResp.Dates, d)
+		}
+		return schedResp, nil
+	}
+	DeferCleanup(func() {
+		getScheduleAPIResp = fetchScheduleAPIResp
+		seasonCache.lock.Lock()
+		seasonCache.seasons = make(map[int]models.Season)
+		seasonCache.lock.Unlock()
+	})
+
+	router := gin.New()
+	router.Use(FieldSelection(models.Game{}))
+	router.GET("/schedule", GetSchedule)
+	return router
+}
+
+// failingWriter is a client that has gone away
+type failingWriter struct {
+	*httptest.ResponseRecorder
+	flushes int
+}
+
+func (w *failingWriter) Write(b []byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+func (w *failingWriter) Flush() {
+	w.flushes++
+}
+
+var _ = Describe("Exporting schedules", Label("Export"), func() {
+	var router *gin.Engine
+
+	BeforeEach(func() {
+		final := newTestGame(1, "2021-09-11T17:05:00Z", 147, 111, "F", "Fenway Park")
+		final.Teams.Away.Team.Name, final.Teams.Home.Team.Name = "New York Yankees", "Boston Red Sox"
+		final.Teams.Away.Score, final.Teams.Home.Score = 5, 3
+		final.Status.DetailedState = "Final"
+		final.DoubleHeader, final.GameNumber = "Y", 1
+		preview := newTestGame(2, "2021-09-11T23:05:00Z", 147, 111, "P", "Fenway Park")
+		preview.Teams.Away.Team.Name, preview.Teams.Home.Team.Name = "New York Yankees", "Boston Red Sox"
+		preview.Status.DetailedState = "Scheduled"
+		preview.Status.StartTimeTBD = true
+		preview.DoubleHeader, preview.GameNumber = "Y", 2
+
+		router = newScheduleRouter(func() []models.Date {
+			return []models.Date{{Date: "2021-09-11", Games: []models.Game{preview, final}}}
+		})
+	})
+
+	get := func(url string, accept string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	When("We request CSV", func() {
+		It("should flatten each game into a row", func(ctx SpecContext) {
+			for _, w := range []*httptest.ResponseRecorder{get("/schedule?teamId=147&date=2021-09-11", "text/csv"), get("/schedule?teamId=147&date=2021-09-11&format=csv", "")} {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Header().Get("Content-Type")).To(Equal(csvContentType))
+
+				records, err := csv.NewReader(w.Body).ReadAll()
+				Expect(err).To(BeNil())
+				Expect(records).To(Equal([][]string{
+					exportColumns,
+					{"1", "2021-09-11", "2021-09-11T17:05:00Z", "", "147", "New York Yankees", "5", "111", "Boston Red Sox", "3", "Final", "Fenway Park", "Y", "1"},
+					{"2", "2021-09-11", "2021-09-11T23:05:00Z", "", "147", "New York Yankees", "", "111", "Boston Red Sox", "", "Scheduled", "Fenway Park", "Y", "2"},
+				}))
+			}
+		})
+	})
+
+	When("We request a page of CSV", func() {
+		It("should link to the next page in a header", func(ctx SpecContext) {
+			// the requested team's games are all on the first page
+			w := get("/schedule?teamId=110&date=2021-09-11&limit=1&format=csv", "")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Header().Get("Link")).To(MatchRegexp(`^</schedule\?.*cursor=.*>; rel="next"$`))
+			Expect(w.Header().Get("ETag")).NotTo(BeEmpty())
+
+			records, err := csv.NewReader(w.Body).ReadAll()
+			Expect(err).To(BeNil())
+			Expect(records).To(HaveLen(2))
+		})
+	})
+
+	When("The client goes away during a CSV export", func() {
+		It("should stop before flushing", func(ctx SpecContext) {
+			// the rows fit in the csv writer's buffer, so only its flush fails
+			games := []models.Game{newTestGame(1, "2021-09-11T23:05:00Z", 147, 111, "P", "Fenway Park")}
+			w := &failingWriter{ResponseRecorder: httptest.NewRecorder()}
+			c, _ := gin.CreateTestContext(w)
+			writeGames(c, formatCSV, []models.Date{{Date: "2021-09-11", Games: games}})
+			Expect(w.flushes).To(BeZero())
+		})
+	})
+
+	When("We request NDJSON", func() {
+		It("should write a game per line", func(ctx SpecContext) {
+			w := get("/schedule?teamId=147&date=2021-09-11", "application/x-ndjson")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Header().Get("Content-Type")).To(Equal(ndjsonContentType))
+
+			games := make([]map[string]interface{}, 0)
+			scanner := bufio.NewScanner(w.Body)
+			for scanner.Scan() {
+				var game map[string]interface{}
+				Expect(json.Unmarshal(scanner.Bytes(), &game)).To(Succeed())
+				games = append(games, game)
+			}
+			Expect(games).To(HaveLen(2))
+			Expect(games[0]["awayScore"]).To(Equal(float64(5)))
+			Expect(games[1]["awayScore"]).To(BeNil())
+		})
+	})
+
+	When("We do not ask for a format", func() {
+		It("should respond with JSON", func(ctx SpecContext) {
+			w := get("/schedule?teamId=147&date=2021-09-11", "application/json")
+			Expect(w.Header().Get("Content-Type")).To(HavePrefix("application/json"))
+			w = get("/schedule?teamId=147&date=2021-09-11&format=json", "text/csv")
+			Expect(w.Header().Get("Content-Type")).To(HavePrefix("application/json"))
+		})
+	})
+
+	When("We ask for an unknown format", func() {
+		It("should return an error", func(ctx SpecContext) {
+			w := get("/schedule?teamId=147&date=2021-09-11&format=xlsx", "")
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(strings.Contains(w.Body.String(), "invalid format")).To(BeTrue())
+		})
+	})
+})
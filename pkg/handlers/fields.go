@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	viewCompact = "compact"
+	viewFull    = "full"
+)
+
+// compactFields are the game fields returned by view=compact, enough to
+// render a scoreboard
+var compactFields = []string{
+	"gamePk",
+	"gameDate",
+	"officialDate",
+	"gameType",
+	"doubleHeader",
+	"gameNumber",
+	"status.abstractGameCode",
+	"status.detailedState",
+	"status.startTimeTBD",
+	"teams.away.team.id",
+	"teams.away.team.name",
+	"teams.away.score",
+	"teams.home.team.id",
+	"teams.home.team.name",
+	"teams.home.score",
+	"venue.name",
+	"localGameTime",
+}
+
+// fieldTree is a set of dotted field paths, a nil subtree selects the whole field
+type fieldTree map[string]fieldTree
+
+func (t fieldTree) add(path []string) {
+	subtree, ok := t[path[0]]
+	if len(path) == 1 {
+		// selecting a field selects everything beneath it
+		t[path[0]] = nil
+		return
+	}
+	if ok && subtree == nil {
+		return
+	}
+	if subtree == nil {
+		subtree = make(fieldTree)
+		t[path[0]] = subtree
+	}
+	subtree.add(path[1:])
+}
+
+// jsonFieldType returns the type of the field serialized under name, looking
+// through embedded structs as encoding/json does
+func jsonFieldType(t reflect.Type, name string) (reflect.Type, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := strings.Split(f.Tag.Get("json"), ",")[0]
+		if f.Anonymous && tag == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if found, ok := jsonFieldType(ft, name); ok {
+					return found, true
+				}
+			}
+			continue
+		}
+		if tag == "-" || !f.IsExported() {
+			continue
+		}
+		if tag == "" {
+			tag = f.Name
+		}
+		if tag == name {
+			return f.Type, true
+		}
+	}
+	return nil, false
+}
+
+// validateFieldPath ensures a dotted path names a serialized field of t
+func validateFieldPath(t reflect.Type, path []string) error {
+	for _, name := range path {
+		for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return fmt.Errorf("invalid field: %s", strings.Join(path, "."))
+		}
+		ft, ok := jsonFieldType(t, name)
+		if !ok {
+			return fmt.Errorf("invalid field: %s", strings.Join(path, "."))
+		}
+		t = ft
+	}
+	return nil
+}
+
+// parseFieldSelection builds the game fields selected by the fields=<path,...>
+// and view=compact|full query parameters, validated against gameType, the type
+// of the games being served. A nil tree selects every field
+func parseFieldSelection(fields string, view string, gameType reflect.Type) (fieldTree, error) {
+	paths := make([]string, 0)
+	switch view {
+	case "", viewFull:
+	case viewCompact:
+		paths = append(paths, compactFields...)
+	default:
+		return nil, fmt.Errorf("invalid view: %s", view)
+	}
+	for _, path := range strings.Split(fields, ",") {
+		path = strings.TrimSpace(path)
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	tree := make(fieldTree)
+	for _, path := range paths {
+		parts := strings.Split(path, ".")
+		err := validateFieldPath(gameType, parts)
+		if err != nil {
+			return nil, err
+		}
+		tree.add(parts)
+	}
+	return tree, nil
+}
+
+// project keeps only the selected fields of a decoded JSON value
+func project(v interface{}, tree fieldTree) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		projected := make(map[string]interface{})
+		for name, subtree := range tree {
+			field, ok := value[name]
+			if !ok {
+				continue
+			}
+			if subtree == nil {
+				projected[name] = field
+			} else {
+				projected[name] = project(field, subtree)
+			}
+		}
+		return projected
+	case []interface{}:
+		projected := make([]interface{}, 0, len(value))
+		for _, elem := range value {
+			projected = append(projected, project(elem, tree))
+		}
+		return projected
+	default:
+		return v
+	}
+}
+
+// projectGames applies the selection to every game in a decoded response,
+// wherever it is nested, leaving the rest of the response intact
+func projectGames(v interface{}, tree fieldTree) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for name, field := range value {
+			if games, ok := field.([]interface{}); ok && name == "games" {
+				value[name] = project(games, tree)
+				continue
+			}
+			value[name] = projectGames(field, tree)
+		}
+		return value
+	case []interface{}:
+		for i := range value {
+			value[i] = projectGames(value[i], tree)
+		}
+		return value
+	default:
+		return v
+	}
+}
+
+// bufferedWriter holds a handler's response body so that it can be rewritten
+// before it is sent
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+// FieldSelection trims the games of successful JSON responses to the fields
+// selected by fields=<path,...> or view=compact, paths are relative to a game
+// (ie. teams.home.team.name) and are validated against game, a value of the
+// type the route serves its games as (ie. TeamScheduleGame for runningRecord).
+// CSV and NDJSON exports have fixed columns and are streamed rather than
+// buffered, so a selection is rejected for them
+func FieldSelection(game interface{}) gin.HandlerFunc {
+	gameType := reflect.TypeOf(game)
+	return func(c *gin.Context) {
+		tree, err := parseFieldSelection(c.Query("fields"), c.Query("view"), gameType)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+			return
+		}
+		if tree == nil {
+			c.Next()
+			return
+		}
+		if format, err := negotiateFormat(c); err == nil && format != formatJSON {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ScheduleErrorResponse{Message: "fields and view are only supported for JSON responses", Timestamp: time.Now().UTC().String()})
+			return
+		}
+
+		w := &bufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		body := w.body.Bytes()
+		if c.Writer.Status() == http.StatusOK && strings.HasPrefix(c.Writer.Header().Get("Content-Type"), "application/json") {
+			var v interface{}
+			decoder := json.NewDecoder(bytes.NewReader(body))
+			decoder.UseNumber()
+			if decoder.Decode(&v) == nil {
+				projected, err := json.Marshal(projectGames(v, tree))
+				if err == nil {
+					body = projected
+				}
+			}
+		}
+		c.Writer.Write(body)
+	}
+}
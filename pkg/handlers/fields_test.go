@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+var _ = Describe("Selecting response fields", Label("Fields"), func() {
+	var router *gin.Engine
+
+	BeforeEach(func() {
+		game := newTestGame(1, "2021-09-11T23:05:00Z", 147, 111, "F", "Fenway Park")
+		game.Status.DetailedState = "Final"
+		game.Teams.Home.Team.Name = "Boston Red Sox"
+
+		router = newScheduleRouter(func() []models.Date {
+			return []models.Date{{Date: "2021-09-11", Games: []models.Game{game}}}
+		})
+	})
+
+	get := func(url string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+		var body map[string]interface{}
+		Expect(json.Unmarshal(w.Body.Bytes(), &body)).To(Succeed())
+		return w.Code, body
+	}
+	firstGame := func(body map[string]interface{}) map[string]interface{} {
+		dates := body["dates"].([]interface{})
+		games := dates[0].(map[string]interface{})["games"].([]interface{})
+		return games[0].(map[string]interface{})
+	}
+
+	When("We select fields", func() {
+		It("should only return the selected fields of each game", func(ctx SpecContext) {
+			code, body := get("/schedule?teamId=147&date=2021-09-11&fields=gamePk,gameDate,status.detailedState,teams.home.team.name")
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(firstGame(body)).To(Equal(map[string]interface{}{
+				"gamePk":   float64(1),
+				"gameDate": "2021-09-11T23:05:00Z",
+				"status":   map[string]interface{}{"detailedState": "Final"},
+				"teams": map[string]interface{}{
+					"home": map[string]interface{}{
+						"team": map[string]interface{}{"name": "Boston Red Sox"},
+					},
+				},
+			}))
+		})
+
+		It("should leave the rest of the response intact", func(ctx SpecContext) {
+			_, body := get("/schedule?teamId=147&date=2021-09-11&fields=gamePk")
+			Expect(body["date"]).To(Equal("2021-09-11"))
+			Expect(body["dates"].([]interface{})[0]).To(HaveKeyWithValue("date", "2021-09-11"))
+		})
+
+		It("should reject fields the Game model does not have", func(ctx SpecContext) {
+			code, body := get("/schedule?teamId=147&date=2021-09-11&fields=gamePk,teams.home.pitcher")
+			Expect(code).To(Equal(http.StatusBadRequest))
+			Expect(body["message"]).To(Equal("invalid field: teams.home.pitcher"))
+		})
+
+		It("should validate fields against the games the route serves", func(ctx SpecContext) {
+			code, body := get("/schedule?teamId=147&date=2021-09-11&fields=runningRecord")
+			Expect(code).To(Equal(http.StatusBadRequest))
+			Expect(body["message"]).To(Equal("invalid field: runningRecord"))
+
+			_, err := parseFieldSelection("gamePk,isIfNecessary", "", reflect.TypeOf(PostseasonGame{}))
+			Expect(err).To(BeNil())
+			_, err = parseFieldSelection("runningRecord.pct", "compact", reflect.TypeOf(TeamScheduleGame{}))
+			Expect(err).To(BeNil())
+		})
+
+		It("should reject a selection of a CSV or NDJSON export", func(ctx SpecContext) {
+			code, body := get("/schedule?teamId=147&date=2021-09-11&fields=gamePk&format=csv")
+			Expect(code).To(Equal(http.StatusBadRequest))
+			Expect(body["message"]).To(Equal("fields and view are only supported for JSON responses"))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/schedule?teamId=147&date=2021-09-11&view=compact", nil)
+			req.Header.Set("Accept", "application/x-ndjson")
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("We request the compact view", func() {
+		It("should return the compact fields", func(ctx SpecContext) {
+			code, body := get("/schedule?teamId=147&date=2021-09-11&view=compact")
+			Expect(code).To(Equal(http.StatusOK))
+			game := firstGame(body)
+			Expect(game).To(HaveKey("gamePk"))
+			Expect(game).To(HaveKey("status"))
+			Expect(game).NotTo(HaveKey("content"))
+			Expect(game["status"]).NotTo(HaveKey("codedGameState"))
+		})
+
+		It("should only name fields the Game model has", func(ctx SpecContext) {
+			_, err := parseFieldSelection(strings.Join(compactFields, ","), "", reflect.TypeOf(models.Game{}))
+			Expect(err).To(BeNil())
+		})
+	})
+
+	When("We request the full view", func() {
+		It("should return every field", func(ctx SpecContext) {
+			_, body := get("/schedule?teamId=147&date=2021-09-11&view=full")
+			Expect(firstGame(body)).To(HaveKey("content"))
+		})
+	})
+
+	When("We request an unknown view", func() {
+		It("should return an error", func(ctx SpecContext) {
+			code, _ := get("/schedule?teamId=147&date=2021-09-11&view=tiny")
+			Expect(code).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
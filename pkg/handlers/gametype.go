@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+const (
+	springTrainingGameType = "S"
+	regularSeasonGameType  = "R"
+	wildCardGameType       = "F"
+	divisionSeriesGameType = "D"
+	leagueSeriesGameType   = "L"
+	worldSeriesGameType    = "W"
+	exhibitionGameType     = "E"
+	allStarGameType        = "A"
+	championshipGameType   = "C"
+	playoffsGameType       = "P"
+	intrasquadGameType     = "I"
+)
+
+// postseasonRounds lists the postseason game types in the order they are played
+var postseasonRounds = []string{wildCardGameType, divisionSeriesGameType, leagueSeriesGameType, worldSeriesGameType}
+
+// validGameTypes are the game types statsapi's gameTypes meta endpoint lists
+// for MLB, C and P are used by older and minor league postseasons
+var validGameTypes = map[string]bool{
+	springTrainingGameType: true,
+	regularSeasonGameType:  true,
+	wildCardGameType:       true,
+	divisionSeriesGameType: true,
+	leagueSeriesGameType:   true,
+	worldSeriesGameType:    true,
+	exhibitionGameType:     true,
+	allStarGameType:        true,
+	championshipGameType:   true,
+	playoffsGameType:       true,
+	intrasquadGameType:     true,
+}
+
+// parseGameTypes builds a set of game types from a comma separated list,
+// ie. gameType=R,F,D,L,W, an empty value returns an empty set
+func parseGameTypes(gameTypeParam string) (map[string]bool, error) {
+	gameTypes := make(map[string]bool)
+	if gameTypeParam == "" {
+		return gameTypes, nil
+	}
+
+	for _, gameType := range strings.Split(gameTypeParam, ",") {
+		gameType = strings.ToUpper(strings.TrimSpace(gameType))
+		if !validGameTypes[gameType] {
+			return nil, fmt.Errorf("invalid game type: %s", gameType)
+		}
+		gameTypes[gameType] = true
+	}
+	return gameTypes, nil
+}
+
+// filterGameTypes returns the games whose type is in gameTypes, an empty set
+// returns all games
+func filterGameTypes(gameTypes map[string]bool, games []models.Game) []models.Game {
+	if len(gameTypes) == 0 {
+		return games
+	}
+
+	i := 0
+	for _, g := range games {
+		if gameTypes[g.GameType] {
+			games[i] = g
+			i++
+		}
+	}
+	return games[:i]
+}
@@ -0,0 +1,510 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/dataloader/v7"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+// maxGraphQLDepth bounds the nesting of a query, deep enough to reach a game's
+// home team's venue's clubs but not to walk the Venue.teams and Team.venue
+// cycle without end
+const maxGraphQLDepth = 8
+
+const graphQLSchema = `
+schema {
+	query: Query
+}
+
+type Query {
+	# games scheduled for a date, limited to the games of teamIds when given.
+	# date accepts the same values as the /schedule API's date parameter
+	schedule(date: String!, teamIds: [Int!]): Schedule!
+	team(id: Int!): Team
+	venue(id: Int!): Venue
+}
+
+type Schedule {
+	date: String!
+	games: [Game!]!
+}
+
+type Game {
+	gamePk: Int!
+	gameType: String!
+	season: String!
+	gameDate: String!
+	officialDate: String!
+	status: Status!
+	teams: Teams!
+	venue: Venue!
+	doubleHeader: String!
+	gameNumber: Int!
+	dayNight: String!
+	seriesDescription: String!
+	seriesGameNumber: Int!
+	gamesInSeries: Int!
+}
+
+type Status {
+	abstractGameState: String!
+	abstractGameCode: String!
+	detailedState: String!
+	startTimeTBD: Boolean!
+}
+
+type Teams {
+	away: ScheduleTeam!
+	home: ScheduleTeam!
+}
+
+type ScheduleTeam {
+	team: Team!
+	# null until the game has started
+	score: Int
+	isWinner: Boolean!
+	leagueRecord: LeagueRecord!
+}
+
+type LeagueRecord {
+	wins: Int!
+	losses: Int!
+	pct: String!
+}
+
+type Team {
+	id: Int!
+	name: String!
+	abbreviation: String!
+	teamName: String!
+	locationName: String!
+	league: League
+	division: Division
+	venue: Venue
+}
+
+type League {
+	id: Int!
+	name: String!
+}
+
+type Division {
+	id: Int!
+	name: String!
+}
+
+type Venue {
+	id: Int!
+	name: String!
+	timeZone: String
+	# clubs that call the venue home
+	teams: [Team!]!
+}
+`
+
+// graphQLResolver resolves the root Query fields, its lookups are overridable in tests
+type graphQLResolver struct {
+	getSchedule  func(date string) (*models.ScheduleResponse, error)
+	lookupTeams  func(ids []int) map[int]models.Team
+	lookupVenues func(ids []int) map[int]VenueResponse
+}
+
+// lookupTeams reads a batch of teams from the team registry under a single lock
+func lookupTeams(ids []int) map[int]models.Team {
+	setLock.RLock()
+	defer setLock.RUnlock()
+
+	teams := make(map[int]models.Team, len(ids))
+	for _, id := range ids {
+		if team, ok := teamSet[id]; ok {
+			teams[id] = team
+		}
+	}
+	return teams
+}
+
+// lookupVenues reads a batch of home venues, with the clubs that call them home
+func lookupVenues(ids []int) map[int]VenueResponse {
+	venues := make(map[int]VenueResponse, len(ids))
+	for _, venue := range getVenues() {
+		venues[venue.ID] = venue
+	}
+	return venues
+}
+
+// graphQLLoaders batch and cache the team and venue lookups made while
+// resolving a single request, so that resolving the teams of every game on a
+// date reads the team registry once rather than once per team
+type graphQLLoaders struct {
+	teams  *dataloader.Loader[int, models.Team]
+	venues *dataloader.Loader[int, VenueResponse]
+
+	lock sync.Mutex
+	// loads queued ahead of the resolvers that need them
+	queued []func()
+}
+
+type graphQLLoadersKey struct{}
+
+// batchFn adapts a lookup returning the values it found to a dataloader batch function
+func batchFn[V any](lookup func([]int) map[int]V, kind string) dataloader.BatchFunc[int, V] {
+	return func(ctx context.Context, ids []int) []*dataloader.Result[V] {
+		found := lookup(ids)
+		results := make([]*dataloader.Result[V], len(ids))
+		for i, id := range ids {
+			v, ok := found[id]
+			if !ok {
+				results[i] = &dataloader.Result[V]{Error: fmt.Errorf("%s not found: %d", kind, id)}
+				continue
+			}
+			results[i] = &dataloader.Result[V]{Data: v}
+		}
+		return results
+	}
+}
+
+func (r *graphQLResolver) newLoaders() *graphQLLoaders {
+	return &graphQLLoaders{
+		teams:  dataloader.NewBatchedLoader(batchFn(r.lookupTeams, "team")),
+		venues: dataloader.NewBatchedLoader(batchFn(r.lookupVenues, "venue")),
+	}
+}
+
+// queue starts loading teams and venues without waiting for them, so that
+// the resolvers that later need them share a single batch
+func (l *graphQLLoaders) queue(ctx context.Context, teamIds []int, venueIds []int) {
+	teams := l.teams.LoadMany(ctx, teamIds)
+	venues := l.venues.LoadMany(ctx, venueIds)
+	l.lock.Lock()
+	l.queued = append(l.queued, func() {
+		teams()
+		venues()
+	})
+	l.lock.Unlock()
+}
+
+// wait blocks until every queued load has finished, so none outlive the request
+func (l *graphQLLoaders) wait() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for _, loaded := range l.queued {
+		loaded()
+	}
+}
+
+func loadersFrom(ctx context.Context) *graphQLLoaders {
+	return ctx.Value(graphQLLoadersKey{}).(*graphQLLoaders)
+}
+
+// loadTeam resolves a team through the request's loader, falling back to the
+// team as embedded in the game when it is not in the registry
+func loadTeam(ctx context.Context, team models.Team) *teamResolver {
+	loaded, err := loadersFrom(ctx).teams.Load(ctx, team.ID)()
+	if err != nil {
+		return &teamResolver{team}
+	}
+	return &teamResolver{loaded}
+}
+
+type scheduleArgs struct {
+	Date    string
+	TeamIds *[]int32
+}
+
+func (r *graphQLResolver) Schedule(ctx context.Context, args scheduleArgs) (*scheduleResolver, error) {
+	date, err := resolveDate(args.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	requested := make(map[int]bool)
+	if args.TeamIds != nil {
+		for _, id := range *args.TeamIds {
+			err = validateTeam(int(id))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %d", err.Error(), id)
+			}
+			requested[int(id)] = true
+		}
+	}
+
+	schedResp, err := r.getSchedule(date)
+	if err != nil {
+		return nil, err
+	}
+
+	games := make([]models.Game, 0)
+	for _, d := range schedResp.Dates {
+		for _, g := range d.Games {
+			if len(requested) == 0 || requested[g.Teams.Away.Team.ID] || requested[g.Teams.Home.Team.ID] {
+				games = append(games, g)
+			}
+		}
+	}
+
+	// queue every team and venue the games refer to, so that nested resolvers
+	// share a single batch rather than racing the loaders' wait window
+	teamIds := make([]int, 0, 2*len(games))
+	venueIds := make([]int, 0, len(games))
+	for _, g := range games {
+		teamIds = append(teamIds, g.Teams.Away.Team.ID, g.Teams.Home.Team.ID)
+		venueIds = append(venueIds, g.Venue.ID)
+	}
+	loadersFrom(ctx).queue(ctx, teamIds, venueIds)
+
+	return &scheduleResolver{date: date, games: games}, nil
+}
+
+func (r *graphQLResolver) Team(ctx context.Context, args struct{ ID int32 }) *teamResolver {
+	team, err := loadersFrom(ctx).teams.Load(ctx, int(args.ID))()
+	if err != nil {
+		return nil
+	}
+	return &teamResolver{team}
+}
+
+func (r *graphQLResolver) Venue(ctx context.Context, args struct{ ID int32 }) *venueResolver {
+	venue, err := loadersFrom(ctx).venues.Load(ctx, int(args.ID))()
+	if err != nil {
+		return nil
+	}
+	return &venueResolver{venue.Venue}
+}
+
+type scheduleResolver struct {
+	date  string
+	games []models.Game
+}
+
+func (r *scheduleResolver) Date() string {
+	return r.date
+}
+
+func (r *scheduleResolver) Games() []*gameResolver {
+	games := make([]*gameResolver, 0, len(r.games))
+	for _, g := range r.games {
+		games = append(games, &gameResolver{g})
+	}
+	return games
+}
+
+type gameResolver struct {
+	g models.Game
+}
+
+func (r *gameResolver) GamePk() int32             { return int32(r.g.GamePk) }
+func (r *gameResolver) GameType() string          { return r.g.GameType }
+func (r *gameResolver) Season() string            { return r.g.Season }
+func (r *gameResolver) GameDate() string          { return r.g.GameDate }
+func (r *gameResolver) OfficialDate() string      { return r.g.OfficialDate }
+func (r *gameResolver) DoubleHeader() string      { return r.g.DoubleHeader }
+func (r *gameResolver) GameNumber() int32         { return int32(r.g.GameNumber) }
+func (r *gameResolver) DayNight() string          { return r.g.DayNight }
+func (r *gameResolver) SeriesDescription() string { return r.g.SeriesDescription }
+func (r *gameResolver) SeriesGameNumber() int32   { return int32(r.g.SeriesGameNumber) }
+func (r *gameResolver) GamesInSeries() int32      { return int32(r.g.GamesInSeries) }
+
+func (r *gameResolver) Status() *statusResolver {
+	return &statusResolver{r.g.Status}
+}
+
+func (r *gameResolver) Teams() *teamsResolver {
+	return &teamsResolver{r.g}
+}
+
+func (r *gameResolver) Venue() *venueResolver {
+	return &venueResolver{r.g.Venue}
+}
+
+type statusResolver struct {
+	s models.Status
+}
+
+func (r *statusResolver) AbstractGameState() string { return r.s.AbstractGameState }
+func (r *statusResolver) AbstractGameCode() string  { return r.s.AbstractGameCode }
+func (r *statusResolver) DetailedState() string     { return r.s.DetailedState }
+func (r *statusResolver) StartTimeTBD() bool        { return r.s.StartTimeTBD }
+
+type teamsResolver struct {
+	g models.Game
+}
+
+func (r *teamsResolver) Away() *scheduleTeamResolver {
+	return &scheduleTeamResolver{r.g.Teams.Away, r.g.Status}
+}
+
+func (r *teamsResolver) Home() *scheduleTeamResolver {
+	return &scheduleTeamResolver{r.g.Teams.Home, r.g.Status}
+}
+
+type scheduleTeamResolver struct {
+	t      models.ScheduleTeam
+	status models.Status
+}
+
+func (r *scheduleTeamResolver) Team(ctx context.Context) *teamResolver {
+	return loadTeam(ctx, r.t.Team)
+}
+
+func (r *scheduleTeamResolver) Score() *int32 {
+	if r.status.AbstractGameCode == "P" {
+		return nil
+	}
+	score := int32(r.t.Score)
+	return &score
+}
+
+func (r *scheduleTeamResolver) IsWinner() bool {
+	return r.t.IsWinner
+}
+
+func (r *scheduleTeamResolver) LeagueRecord() *leagueRecordResolver {
+	return &leagueRecordResolver{r.t.LeagueRecord}
+}
+
+type leagueRecordResolver struct {
+	r models.LeagueRecord
+}
+
+func (r *leagueRecordResolver) Wins() int32   { return int32(r.r.Wins) }
+func (r *leagueRecordResolver) Losses() int32 { return int32(r.r.Losses) }
+func (r *leagueRecordResolver) Pct() string   { return r.r.Pct }
+
+type teamResolver struct {
+	t models.Team
+}
+
+func (r *teamResolver) ID() int32            { return int32(r.t.ID) }
+func (r *teamResolver) Name() string         { return r.t.Name }
+func (r *teamResolver) Abbreviation() string { return r.t.Abbreviation }
+func (r *teamResolver) TeamName() string     { return r.t.TeamName }
+func (r *teamResolver) LocationName() string { return r.t.LocationName }
+
+func (r *teamResolver) League() *leagueResolver {
+	if r.t.League == nil {
+		return nil
+	}
+	return &leagueResolver{int32(r.t.League.ID), r.t.League.Name}
+}
+
+func (r *teamResolver) Division() *leagueResolver {
+	if r.t.Division == nil {
+		return nil
+	}
+	return &leagueResolver{int32(r.t.Division.ID), r.t.Division.Name}
+}
+
+func (r *teamResolver) Venue() *venueResolver {
+	if r.t.Venue == nil {
+		return nil
+	}
+	return &venueResolver{*r.t.Venue}
+}
+
+// leagueResolver resolves both leagues and divisions
+type leagueResolver struct {
+	id   int32
+	name string
+}
+
+func (r *leagueResolver) ID() int32    { return r.id }
+func (r *leagueResolver) Name() string { return r.name }
+
+type venueResolver struct {
+	v models.Venue
+}
+
+func (r *venueResolver) ID() int32    { return int32(r.v.ID) }
+func (r *venueResolver) Name() string { return r.v.Name }
+
+func (r *venueResolver) TimeZone() *string {
+	if r.v.TimeZone == nil {
+		return nil
+	}
+	return &r.v.TimeZone.ID
+}
+
+func (r *venueResolver) Teams(ctx context.Context) []*teamResolver {
+	teams := make([]*teamResolver, 0)
+	// neutral sites are not home to any club
+	venue, err := loadersFrom(ctx).venues.Load(ctx, r.v.ID)()
+	if err != nil {
+		return teams
+	}
+	for _, team := range venue.Teams {
+		teams = append(teams, loadTeam(ctx, team))
+	}
+	return teams
+}
+
+// graphQLServer executes GraphQL requests against the schema, with fresh
+// loaders for each request
+type graphQLServer struct {
+	schema   *graphql.Schema
+	resolver *graphQLResolver
+}
+
+func newGraphQLServer(resolver *graphQLResolver) *graphQLServer {
+	return &graphQLServer{
+		schema:   graphql.MustParseSchema(graphQLSchema, resolver, graphql.MaxDepth(maxGraphQLDepth)),
+		resolver: resolver,
+	}
+}
+
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func (s *graphQLServer) serve(c *gin.Context) {
+	var req graphQLRequest
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			err := json.Unmarshal([]byte(variables), &req.Variables)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: "invalid variables", Timestamp: time.Now().UTC().String()})
+				return
+			}
+		}
+	} else {
+		err := c.ShouldBindJSON(&req)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: "invalid GraphQL request: " + err.Error(), Timestamp: time.Now().UTC().String()})
+			return
+		}
+	}
+	if req.Query == "" {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: "query is required", Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	loaders := s.resolver.newLoaders()
+	ctx := context.WithValue(c.Request.Context(), graphQLLoadersKey{}, loaders)
+	resp := s.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	loaders.wait()
+	c.JSON(http.StatusOK, resp)
+}
+
+var defaultGraphQLServer = newGraphQLServer(&graphQLResolver{
+	getSchedule:  getDateSchedule,
+	lookupTeams:  lookupTeams,
+	lookupVenues: lookupVenues,
+})
+
+// ServeGraphQL serves the /graphql API, a POSTed {"query", "operationName",
+// "variables"} body or GET query parameters of the same names, which resolves
+// schedules, teams and venues from the same sources as the REST APIs
+func ServeGraphQL(c *gin.Context) {
+	defaultGraphQLServer.serve(c)
+}
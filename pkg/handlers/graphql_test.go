@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+var _ = Describe("Processing GraphQL requests", Label("GraphQL"), func() {
+	var (
+		router      *gin.Engine
+		lock        sync.Mutex
+		teamBatches [][]int
+		venueCalls  int
+	)
+
+	BeforeEach(func() {
+		var teamResp models.TeamsResponse
+		setLock = new(sync.RWMutex)
+		err := json.Unmarshal([]byte(teamsAPIJSON), &teamResp)
+		if err != nil {
+			os.Exit(1)
+		}
+		createTeamsSet(teamResp)
+		teamBatches, venueCalls = nil, 0
+
+		// 15 games between 30 different clubs
+		teams := getTeams()
+		games := make([]models.Game, 0, 15)
+		for i := 0; i < 15; i++ {
+			away, home := teams[2*i], teams[2*i+1]
+			game := newTestGame(i+1, "2021-09-11T23:05:00Z", away.ID, home.ID, "P", home.Venue.Name)
+			game.Venue.ID = home.Venue.ID
+			games = append(games, game)
+		}
+		games[0].Status.AbstractGameCode = "F"
+		games[0].Teams.Away.Score, games[0].Teams.Home.Score = 5, 3
+
+		server := newGraphQLServer(&graphQLResolver{
+			getSchedule: func(date string) (*models.ScheduleResponse, error) {
+				if date != "2021-09-11" {
+					return nil, errors.New("statsapi unreachable")
+				}
+				return &models.ScheduleResponse{Dates: []models.Date{{Date: date, Games: games}}}, nil
+			},
+			lookupTeams: func(ids []int) map[int]models.Team {
+				lock.Lock()
+				teamBatches = append(teamBatches, ids)
+				lock.Unlock()
+				return lookupTeams(ids)
+			},
+			lookupVenues: func(ids []int) map[int]VenueResponse {
+				lock.Lock()
+				venueCalls++
+				lock.Unlock()
+				return lookupVenues(ids)
+			},
+		})
+		router = gin.New()
+		router.POST("/graphql", server.serve)
+		router.GET("/graphql", server.serve)
+	})
+
+	type graphQLResponse struct {
+		Data   map[string]interface{} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	post := func(query string, variables map[string]interface{}) graphQLResponse {
+		b, _ := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(b))))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var resp graphQLResponse
+		Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+		return resp
+	}
+
+	When("We query a schedule's nested teams and venues", func() {
+		It("should batch the lookups", func(ctx SpecContext) {
+			resp := post(`{
+				schedule(date: "2021-09-11") {
+					games {
+						gamePk
+						teams {
+							away { score team { name } }
+							home { score team { name venue { name teams { abbreviation } } } }
+						}
+					}
+				}
+			}`, nil)
+			Expect(resp.Errors).To(BeEmpty())
+
+			games := resp.Data["schedule"].(map[string]interface{})["games"].([]interface{})
+			Expect(games).To(HaveLen(15))
+			first := games[0].(map[string]interface{})["teams"].(map[string]interface{})
+			Expect(first["away"].(map[string]interface{})["score"]).To(Equal(float64(5)))
+			second := games[1].(map[string]interface{})["teams"].(map[string]interface{})
+			Expect(second["away"].(map[string]interface{})["score"]).To(BeNil())
+			home := second["home"].(map[string]interface{})["team"].(map[string]interface{})
+			Expect(home["venue"].(map[string]interface{})["teams"]).To(HaveLen(1))
+
+			Expect(teamBatches).To(HaveLen(1))
+			Expect(teamBatches[0]).To(HaveLen(30))
+			Expect(venueCalls).To(Equal(1))
+		})
+
+		It("should only return the requested teams' games", func(ctx SpecContext) {
+			teams := getTeams()
+			resp := post(`query($ids: [Int!]) { schedule(date: "2021-09-11", teamIds: $ids) { date games { gamePk } } }`,
+				map[string]interface{}{"ids": []int{teams[0].ID, teams[5].ID}})
+			Expect(resp.Errors).To(BeEmpty())
+			schedule := resp.Data["schedule"].(map[string]interface{})
+			Expect(schedule["date"]).To(Equal("2021-09-11"))
+			Expect(schedule["games"]).To(Equal([]interface{}{
+				map[string]interface{}{"gamePk": float64(1)},
+				map[string]interface{}{"gamePk": float64(3)},
+			}))
+		})
+
+		It("should reject queries nested deeper than the limit", func(ctx SpecContext) {
+			resp := post(`{ venue(id: 3) { teams { venue { teams { venue { teams { venue { teams { venue { name } } } } } } } } } }`, nil)
+			Expect(resp.Errors).NotTo(BeEmpty())
+			Expect(resp.Data["venue"]).To(BeNil())
+			Expect(venueCalls).To(BeZero())
+		})
+
+		It("should return errors for invalid arguments", func(ctx SpecContext) {
+			resp := post(`{ schedule(date: "2021-13-45") { date } }`, nil)
+			Expect(resp.Errors).NotTo(BeEmpty())
+			resp = post(`{ schedule(date: "2021-09-11", teamIds: [1]) { date } }`, nil)
+			Expect(resp.Errors[0].Message).To(Equal("team not found: 1"))
+			resp = post(`{ schedule(date: "2021-09-12") { date } }`, nil)
+			Expect(resp.Errors[0].Message).To(Equal("statsapi unreachable"))
+		})
+	})
+
+	When("We query a team and a venue", func() {
+		It("should resolve them from the registry", func(ctx SpecContext) {
+			resp := post(`{ team(id: 147) { name abbreviation division { name } venue { id } } venue(id: 3) { name teams { id } } missing: team(id: 1) { name } }`, nil)
+			Expect(resp.Errors).To(BeEmpty())
+			team := resp.Data["team"].(map[string]interface{})
+			Expect(team["name"]).To(Equal("New York Yankees"))
+			Expect(team["venue"]).To(Equal(map[string]interface{}{"id": float64(3313)}))
+			Expect(resp.Data["venue"]).To(Equal(map[string]interface{}{
+				"name":  "Fenway Park",
+				"teams": []interface{}{map[string]interface{}{"id": float64(111)}},
+			}))
+			Expect(resp.Data["missing"]).To(BeNil())
+		})
+
+		It("should accept GET requests", func(ctx SpecContext) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape(`{ team(id: 111) { abbreviation } }`), nil))
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(Equal(`{"data":{"team":{"abbreviation":"BOS"}}}`))
+		})
+	})
+
+	When("We do not send a query", func() {
+		It("should return an error", func(ctx SpecContext) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{}`)))
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"strconv"
 	"sync"
 	"time"
 
@@ -14,49 +13,86 @@ import (
 	"github.com/stefanKnott/mlbtakehome/pkg/models"
 )
 
-var teamSet map[int]string
+var teamSet map[int]models.Team
 var setLock *sync.RWMutex
 
 const (
-	teamsAPI          = "https://statsapi.mlb.com/api/v1/teams?season=2021&sportId=1"
-	scheuldeAPIFmtStr = "https://statsapi.mlb.com/api/v1/schedule?date=%s&sportId=1&language=en"
+	teamsAPIFmtStr           = "https://statsapi.mlb.com/api/v1/teams?season=%d&sportId=1"
+	scheuldeAPIFmtStr        = "https://statsapi.mlb.com/api/v1/schedule?date=%s&sportId=1&language=en&hydrate=venue(timezone)"
+	scheduleRangeAPIFmtStr   = "https://statsapi.mlb.com/api/v1/schedule?startDate=%s&endDate=%s&sportId=1&language=en&hydrate=venue(timezone)"
+	teamScheduleAPIFmtStr    = "https://statsapi.mlb.com/api/v1/schedule?teamId=%d&season=%s&sportId=1&language=en&hydrate=venue(timezone)"
+	teamRangeAPIFmtStr       = "https://statsapi.mlb.com/api/v1/schedule?teamId=%d&startDate=%s&endDate=%s&sportId=1&language=en&hydrate=venue(timezone)"
+	regularSeasonAPIFmtStr   = "https://statsapi.mlb.com/api/v1/schedule?startDate=%s&endDate=%s&sportId=1&gameType=R&language=en"
+	standingsAPIFmtStr       = "https://statsapi.mlb.com/api/v1/standings?leagueId=103,104&season=%d&date=%s"
+	venueScheduleAPIFmtStr   = "https://statsapi.mlb.com/api/v1/schedule?venueIds=%d&startDate=%s&endDate=%s&sportId=1&language=en&hydrate=venue(timezone)"
+	postseasonAPIFmtStr      = "https://statsapi.mlb.com/api/v1/schedule/postseason?season=%s&sportId=1&language=en"
+	postseasonRangeAPIFmtStr = "https://statsapi.mlb.com/api/v1/schedule?startDate=%s&endDate=%s&sportId=1&gameType=F,D,L,W&language=en"
+	seasonAPIFmtStr          = "https://statsapi.mlb.com/api/v1/seasons/%d?sportId=1"
 )
 
 // structs for /schedule API responses
 type ScheduleResponse struct {
 	models.ScheduleResponse
+	// canonical YYYY-MM-DD date a relative date or keyword resolved to
+	Date string `json:"date,omitempty"`
+	// set when date falls outside of a season
+	OffSeason *OffSeason `json:"offSeason,omitempty"`
+	// link to the next page of games when limit is set
+	Next string `json:"next,omitempty"`
 }
 
 type ScheduleErrorResponse struct {
 	Message   string `json:"message"`
 	Timestamp string `json:"timestamp"`
+	// teams an ambiguous team query could refer to
+	Candidates []TeamCandidate `json:"candidates,omitempty"`
+}
+
+// InvalidQueryError is returned by Schedule when the query itself is invalid,
+// as opposed to the schedule failing to load
+type InvalidQueryError struct {
+	Err error
+}
+
+func (e *InvalidQueryError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InvalidQueryError) Unwrap() error {
+	return e.Err
 }
 
 func createTeamsSet(teamsResp models.TeamsResponse) {
 	setLock.Lock()
-	teamSet = make(map[int]string)
-	teamSet[159] = "American League All-Stars"
-	teamSet[160] = "National League All-Stars"
+	teamSet = make(map[int]models.Team)
+	teamSet[159] = models.Team{ID: 159, Name: "American League All-Stars"}
+	teamSet[160] = models.Team{ID: 160, Name: "National League All-Stars"}
 	for _, team := range teamsResp.Teams {
-		teamSet[team.ID] = team.Name
+		teamSet[team.ID] = team
 	}
+	buildTeamIndex()
 	setLock.Unlock()
 }
 
-func getTeamsAPIResp() (*models.TeamsResponse, error) {
-	res, err := http.Get(teamsAPI)
+// getJSON decodes the JSON body served at url into v
+func getJSON(url string, v interface{}) error {
+	res, err := http.Get(url)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer res.Body.Close()
 
-	var teamsResp *models.TeamsResponse
 	b, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	err = json.Unmarshal(b, &teamsResp)
+	return json.Unmarshal(b, v)
+}
+
+func getTeamsAPIResp() (*models.TeamsResponse, error) {
+	var teamsResp *models.TeamsResponse
+	err := getJSON(fmt.Sprintf(teamsAPIFmtStr, currentSeason()), &teamsResp)
 	if err != nil {
 		return nil, err
 	}
@@ -64,6 +100,40 @@ func getTeamsAPIResp() (*models.TeamsResponse, error) {
 	return teamsResp, nil
 }
 
+// getScheduleAPIResp fetches a statsapi schedule, tests replace it to serve
+// schedules without statsapi
+var getScheduleAPIResp = fetchScheduleAPIResp
+
+func fetchScheduleAPIResp(url string) (*models.ScheduleResponse, error) {
+	var schedResp *models.ScheduleResponse
+	err := getJSON(url, &schedResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return schedResp, nil
+}
+
+func getStandingsAPIResp(url string) (*models.StandingsResponse, error) {
+	var standingsResp *models.StandingsResponse
+	err := getJSON(url, &standingsResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return standingsResp, nil
+}
+
+func getSeasonAPIResp(year int) (*models.SeasonsResponse, error) {
+	var seasonsResp *models.SeasonsResponse
+	err := getJSON(fmt.Sprintf(seasonAPIFmtStr, year), &seasonsResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return seasonsResp, nil
+}
+
 func InitTeamIdSet() {
 	setLock = new(sync.RWMutex)
 	ticker := time.NewTicker(30 * time.Minute)
@@ -74,15 +144,35 @@ func InitTeamIdSet() {
 			teamsResp, err := getTeamsAPIResp()
 			if err != nil {
 				fmt.Printf("got err when hitting teams API: %s\n", err.Error())
+				loadStoredTeams()
 				continue
 			}
 
 			createTeamsSet(*teamsResp)
+			persistTeams(*teamsResp)
 			<-ticker.C
 		}
 	}()
 }
 
+// LoadTeams populates the team registry once, for commands that do not run
+// the background refresh started by InitTeamIdSet
+func LoadTeams() error {
+	setLock = new(sync.RWMutex)
+	teamsResp, err := getTeamsAPIResp()
+	if err != nil {
+		loadStoredTeams()
+		if teamSet == nil {
+			return err
+		}
+		return nil
+	}
+
+	createTeamsSet(*teamsResp)
+	persistTeams(*teamsResp)
+	return nil
+}
+
 func sortDoubleHeaders(games []models.Game) ([]models.Game, error) {
 	var chronoFirst, chronoSecond models.Game
 	zerothIdxGame := games[0]
@@ -128,23 +218,32 @@ func sortDoubleHeaders(games []models.Game) ([]models.Game, error) {
 	return []models.Game{chronoFirst, chronoSecond}, nil
 }
 
-func validateQueryParameters(id int, date string) error {
-	// validate requested team ID exists
+// validateTeam ensures the requested team ID exists
+func validateTeam(id int) error {
 	setLock.RLock()
-	myTeam := teamSet[id]
+	_, ok := teamSet[id]
 	setLock.RUnlock()
 
-	if myTeam == "" {
-		return  errors.New("team not found")
+	if !ok {
+		return errors.New("team not found")
+	}
+	return nil
+}
+
+func validateQueryParameters(id int, date string) error {
+	// validate requested team ID exists
+	err := validateTeam(id)
+	if err != nil {
+		return err
 	}
 
 	// validate timestamp
-	_, err := time.Parse("2006-01-02", date)
+	_, err = time.Parse("2006-01-02", date)
 	if err != nil {
 		return errors.New("invalid date string")
 	}
 
-	return  nil
+	return nil
 }
 
 // give a slice of games, filter out all games that myTeam is either home or away
@@ -166,69 +265,206 @@ func filterTeam(id int, games []models.Game) (myTeamsGames []models.Game, otherT
 
 }
 
+// orderGames lists the requested team's games first, with double headers in
+// chronological order, followed by all other games ordered by less
+func orderGames(id int, games []models.Game, less gameComparator) ([]models.Game, error) {
+	// filter myTeam games out of schedule response payload into standalone slices
+	myTeamsGames, otherTeamsGames := filterTeam(id, games)
+	sortGames(otherTeamsGames, less)
+
+	// build ordered response payload
+	ordered := make([]models.Game, 0, len(myTeamsGames)+len(otherTeamsGames))
+	if isSplitSquad(id, myTeamsGames) {
+		// split squad games are played simultaneously, list live games first then by first pitch
+		sortGames(myTeamsGames, chainComparators(byLive, byFirstPitch))
+		ordered = append(ordered, myTeamsGames...)
+	} else if len(myTeamsGames) == 2 {
+		dhGames, err := sortDoubleHeaders(myTeamsGames)
+		if err != nil {
+			return nil, err
+		}
+		ordered = append(ordered, dhGames...)
+	} else {
+		ordered = append(ordered, myTeamsGames...)
+	}
+	return append(ordered, otherTeamsGames...), nil
+}
+
+// ScheduleQuery holds the parameters of a /schedule request
+type ScheduleQuery struct {
+	TeamID   int
+	Date     string
+	GameType string
+	Sort     string
+	TimeZone string
+}
+
+// scheduleRequest is a validated ScheduleQuery
+type scheduleRequest struct {
+	id        int
+	date      string
+	gameTypes map[string]bool
+	less      gameComparator
+	loc       *time.Location
+}
+
+// parseScheduleQuery validates a ScheduleQuery, any error is the client's
+func parseScheduleQuery(q ScheduleQuery) (*scheduleRequest, error) {
+	date, err := resolveDate(q.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateQueryParameters(q.TeamID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	gameTypes, err := parseGameTypes(q.GameType)
+	if err != nil {
+		return nil, err
+	}
+
+	if gameTypes[springTrainingGameType] {
+		err = validateSpringLeague(q.TeamID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	less, err := parseSortParameter(q.TeamID, q.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := parseTimeZone(q.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	// checked last as it may fetch the season calendar
+	err = validateSeasonDate(date)
+	if err != nil {
+		return nil, err
+	}
+
+	return &scheduleRequest{id: q.TeamID, date: date, gameTypes: gameTypes, less: less, loc: loc}, nil
+}
+
+// schedule fetches the requested date and filters, orders and annotates its games
+func (r *scheduleRequest) schedule() (*ScheduleResponse, error) {
+	var schedResp *models.ScheduleResponse
+	var err error
+	if r.loc != nil {
+		schedResp, err = getScheduleForLocalDate(r.date, r.loc)
+	} else {
+		schedResp, err = getDateSchedule(r.date)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// statsapi omits dates without games
+	if len(schedResp.Dates) == 0 {
+		schedResp.Dates = []models.Date{{Date: r.date, Games: make([]models.Game, 0)}}
+	}
+
+	// unexpected response, should only contain one date
+	if len(schedResp.Dates) != 1 {
+		return nil, errors.New("received invalid dates slice from schedule API")
+	}
+
+	schedResp.Dates[0].Games = filterGameTypes(r.gameTypes, schedResp.Dates[0].Games)
+	schedResp.Dates[0].Games, err = orderGames(r.id, schedResp.Dates[0].Games, r.less)
+	if err != nil {
+		return nil, err
+	}
+	localizeGames(schedResp.Dates[0].Games, r.loc)
+	addPerspectives(r.id, schedResp.Dates[0].Games)
+
+	// pass thru empty events until we find the object definition
+	schedResp.Events = make([]models.Event, 0)
+	resp := &ScheduleResponse{ScheduleResponse: *schedResp, Date: r.date}
+	if len(schedResp.Dates[0].Games) == 0 {
+		// an off-season date is not an error, the season calendar is best effort
+		resp.OffSeason, _ = getOffSeason(r.date)
+	}
+	return resp, nil
+}
+
+// Schedule returns the games scheduled for a date exactly as the /schedule API does
+func Schedule(q ScheduleQuery) (*ScheduleResponse, error) {
+	r, err := parseScheduleQuery(q)
+	if err != nil {
+		return nil, &InvalidQueryError{Err: err}
+	}
+	return r.schedule()
+}
+
 // GetSchedule serves the /schedule?teamId=<id>&date=<YYYY-MM-DD> API
 // which allows a client to receive a list ofgames scheduled for a specific date
-// with the requested team's games ordered first
+// with the requested team's games ordered first, team=<abbreviation or name> may
+// be given in place of teamId. An optional sort=<strategy,...>
+// orders the remaining games and gameType=<type,...> limits the games returned.
+// An optional tz=<IANA zone> interprets date in that zone and localizes start times.
+// date may also be relative to today (ie. today or +3d) or a season keyword (ie.
+// opening-day), the resolved date is echoed in the response. An optional
+// limit=<n> pages the games, with a next link carrying the cursor to the next page.
+// format=<csv|ndjson>, or the Accept header, streams the games as flat rows.
+// Responses carry an ETag, answering If-None-Match with a 304, and a
+// Cache-Control max-age chosen by the state of the games
 func GetSchedule(c *gin.Context) {
-	date := c.Query("date")
-	teamId := c.Query("teamId")
-	id, err := strconv.Atoi(teamId)
+	format, err := negotiateFormat(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
 		return
 	}
 
-	err = validateQueryParameters(id, date)
+	id, err := parseTeamParameter(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		c.JSON(http.StatusBadRequest, teamErrorResponse(err))
 		return
 	}
 
-	res, err := http.Get(fmt.Sprintf(scheuldeAPIFmtStr, date))
+	r, err := parseScheduleQuery(ScheduleQuery{
+		TeamID:   id,
+		Date:     c.Query("date"),
+		GameType: c.Query("gameType"),
+		Sort:     c.Query("sort"),
+		TimeZone: c.Query("tz"),
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
 		return
 	}
-	defer res.Body.Close()
 
-	var schedResp models.ScheduleResponse
-	b, err := ioutil.ReadAll(res.Body)
+	limit, cursor, err := parsePageParameters(c.Query("limit"), c.Query("cursor"), r.date)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
 		return
 	}
 
-	err = json.Unmarshal(b, &schedResp)
+	schedResp, err := r.schedule()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
 		return
 	}
 
-	// unexpected response, should only contain one date
-	if len(schedResp.Dates) != 1 {
-		c.JSON(http.StatusInternalServerError, ScheduleErrorResponse{Message: "received invalid dates slice from schedule API", Timestamp: time.Now().UTC().String()})
+	games, next := paginateGames(r.id, r.date, schedResp.Dates[0].Games, limit, cursor, r.less)
+	schedResp.Dates[0].Games = games
+	if next != nil {
+		schedResp.Next = nextLink(c, *next)
+	}
+	if writeCacheHeaders(c, format, schedResp) {
 		return
 	}
-
-	myTeamsGames := make([]models.Game, 0)
-	// filter myTeam games out of schedule response payload into standalone slices
-	myTeamsGames, schedResp.Dates[0].Games = filterTeam(id, schedResp.Dates[0].Games)
-
-	// build ordered response payload
-	tmp := schedResp.Dates[0].Games
-	schedResp.Dates[0].Games = make([]models.Game, 0)
-	if len(myTeamsGames) == 2 {
-		dhGames, err := sortDoubleHeaders(myTeamsGames)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
-			return
+	if format != formatJSON {
+		// exports carry the next page's link in a header, as they have no envelope
+		if schedResp.Next != "" {
+			c.Header("Link", fmt.Sprintf("<%s>; rel=\"next\"", schedResp.Next))
 		}
-		schedResp.Dates[0].Games = append(schedResp.Dates[0].Games, dhGames...)
-	} else {
-		schedResp.Dates[0].Games = append(schedResp.Dates[0].Games, myTeamsGames...)
+		writeGames(c, format, schedResp.Dates)
+		return
 	}
-	schedResp.Dates[0].Games = append(schedResp.Dates[0].Games, tmp...)
-	// pass thru empty events until we find the object definition
-	schedResp.Events = make([]models.Event, 0)
-	c.JSON(http.StatusOK, ScheduleResponse{schedResp})
+	c.JSON(http.StatusOK, schedResp)
 }
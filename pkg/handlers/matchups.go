@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+// structs for /matchups API responses
+type MatchupResponse struct {
+	Team     models.Team  `json:"team"`
+	Opponent models.Team  `json:"opponent"`
+	Season   string       `json:"season"`
+	Record   Record       `json:"record"`
+	Series   []TeamSeries `json:"series"`
+	Next     string       `json:"next,omitempty"`
+}
+
+// filterOpponent returns the dates on which the opponent was played, keeping
+// only the games against it
+func filterOpponent(opponentId int, dates []models.Date) []models.Date {
+	filtered := make([]models.Date, 0)
+	for _, d := range dates {
+		opponentGames, _ := filterTeam(opponentId, d.Games)
+		if len(opponentGames) == 0 {
+			continue
+		}
+		d.Games = opponentGames
+		filtered = append(filtered, d)
+	}
+	return filtered
+}
+
+// GetMatchups serves the /matchups?teamId=<id>&opponentId=<id>&season=<YYYY>
+// API which allows a client to receive every game between two teams in a
+// season grouped by series, along with the season series record,
+// gameType=<type,...> defaults to the regular season. An optional limit=<n>
+// pages the games across dates
+func GetMatchups(c *gin.Context) {
+	format, err := negotiateFormat(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Query("teamId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	opponentId, err := strconv.Atoi(c.Query("opponentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	for _, teamId := range []int{id, opponentId} {
+		err = validateTeam(teamId)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+			return
+		}
+	}
+	if id == opponentId {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: "team and opponent must differ", Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	season := c.Query("season")
+	err = validateSeason(season)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	gameTypes, err := parseGameTypes(c.DefaultQuery("gameType", regularSeasonGameType))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	limit, cursor, err := parsePageParameters(c.Query("limit"), c.Query("cursor"), "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	schedResp, err := getTeamSeasonSchedule(id, season)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	for i := range schedResp.Dates {
+		games := filterGameTypes(gameTypes, schedResp.Dates[i].Games)
+		// pages follow the order games are listed in
+		schedResp.Dates[i].Games, err = orderGames(id, games, nil)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+			return
+		}
+	}
+
+	dates := filterOpponent(opponentId, schedResp.Dates)
+	page, next := paginateDates(dates, limit, cursor)
+	if format != formatJSON {
+		// exports carry the next page's link in a header, as they have no envelope
+		if next != nil {
+			c.Header("Link", fmt.Sprintf("<%s>; rel=\"next\"", nextLink(c, *next)))
+		}
+		writeGames(c, format, page)
+		return
+	}
+
+	teamSchedule, err := buildTeamSchedule(id, dates)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	// the season series record is tallied over every game, then trimmed to the page
+	resp := MatchupResponse{Season: season, Record: teamSchedule.Record, Series: pageSeries(teamSchedule.Series, onPage(page))}
+	resp.Team, _ = getTeam(id)
+	resp.Opponent, _ = getTeam(opponentId)
+	if next != nil {
+		resp.Next = nextLink(c, *next)
+	}
+	c.JSON(http.StatusOK, resp)
+}
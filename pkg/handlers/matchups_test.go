@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+var _ = Describe("Processing Matchup requests", Label("Matchups"), func() {
+	When("We filter a team's schedule by opponent", func() {
+		dates := []models.Date{
+			{Date: "2021-04-01", Games: []models.Game{newTestSeriesGame(1, "2021-04-01T17:05:00Z", 147, 141, 1, true)}},
+			{Date: "2021-04-02", Games: []models.Game{newTestSeriesGame(2, "2021-04-02T17:05:00Z", 147, 111, 2, false)}},
+			{Date: "2021-04-03", Games: []models.Game{newTestSeriesGame(3, "2021-04-03T17:05:00Z", 141, 147, 3, false)}},
+		}
+
+		It("should only keep the dates and games against the opponent", func(ctx SpecContext) {
+			filtered := filterOpponent(141, dates)
+			Expect(filtered).To(HaveLen(2))
+			Expect(filtered[0].Date).To(Equal("2021-04-01"))
+			Expect(filtered[1].Date).To(Equal("2021-04-03"))
+		})
+
+		It("should tally the season series record", func(ctx SpecContext) {
+			matchups, err := buildTeamSchedule(147, filterOpponent(141, dates))
+			Expect(err).To(BeNil())
+			Expect(matchups.Series).To(HaveLen(2))
+			Expect(matchups.Record).To(Equal(Record{Wins: 0, Losses: 2, Pct: ".000"}))
+		})
+
+		It("should count a suspended and resumed game once in the series and season records", func(ctx SpecContext) {
+			suspended := newTestSeriesGame(4, "2021-04-04T17:05:00Z", 141, 147, 3, true)
+			suspended.Status.DetailedState = "Suspended: Rain"
+			resumed := newTestSeriesGame(4, "2021-04-05T17:05:00Z", 141, 147, 3, true)
+			withResumed := append(append([]models.Date{}, dates...),
+				models.Date{Date: "2021-04-04", Games: []models.Game{suspended}},
+				models.Date{Date: "2021-04-05", Games: []models.Game{resumed}},
+			)
+
+			matchups, err := buildTeamSchedule(147, filterOpponent(141, withResumed))
+			Expect(err).To(BeNil())
+			Expect(matchups.Series).To(HaveLen(2))
+			Expect(matchups.Series[1].Games).To(HaveLen(3))
+			Expect(matchups.Series[1].Record).To(Equal(Record{Wins: 1, Losses: 1, Pct: ".500"}))
+			Expect(matchups.Record).To(Equal(Record{Wins: 1, Losses: 2, Pct: ".333"}))
+		})
+	})
+})
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+)
+
+// openAPISpec is the OpenAPI 3 document describing the /api/v1 APIs, its
+// schemas are checked against the types the APIs serialize by openapi_test.go
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// loadOpenAPI parses the OpenAPI document and builds a router matching
+// requests to its operations
+func loadOpenAPI(spec []byte) (*openapi3.T, routers.Router, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = doc.Validate(context.Background())
+	if err != nil {
+		return nil, nil, err
+	}
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return doc, router, nil
+}
+
+// requestValidationMessage describes why a request does not match the document
+func requestValidationMessage(err error) string {
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return err.Error()
+	}
+
+	reason := reqErr.Reason
+	var schemaErr *openapi3.SchemaError
+	switch {
+	case errors.As(reqErr.Err, &schemaErr):
+		reason = schemaErr.Reason
+	case errors.Is(reqErr.Err, openapi3filter.ErrInvalidRequired):
+		reason = "is required"
+	case reason == "" && reqErr.Err != nil:
+		reason = reqErr.Err.Error()
+	}
+
+	if reqErr.Parameter != nil {
+		return fmt.Sprintf("invalid %s parameter %s: %s", reqErr.Parameter.In, reqErr.Parameter.Name, reason)
+	}
+	if reqErr.RequestBody != nil {
+		return "invalid request body: " + reason
+	}
+	return reason
+}
+
+// RequestValidation rejects requests whose parameters or body do not match
+// the OpenAPI document with a 400, requests for paths the document does not
+// describe are passed through untouched
+func RequestValidation() gin.HandlerFunc {
+	_, router, err := loadOpenAPI(openAPISpec)
+	if err != nil {
+		panic(fmt.Sprintf("got err when loading OpenAPI document: %s", err.Error()))
+	}
+
+	return func(c *gin.Context) {
+		route, pathParams, err := router.FindRoute(c.Request)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		err = openapi3filter.ValidateRequest(c.Request.Context(), &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+		})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ScheduleErrorResponse{Message: requestValidationMessage(err), Timestamp: time.Now().UTC().String()})
+			return
+		}
+		c.Next()
+	}
+}
+
+// GetOpenAPI serves the /openapi.json API which describes every /api/v1 API
+func GetOpenAPI(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", openAPISpec)
+}
@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+// schemaGenerator builds OpenAPI schemas for Go types the way encoding/json
+// serializes them, named structs become components referenced by name
+type schemaGenerator struct {
+	schemas map[string]interface{}
+	types   map[string]reflect.Type
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{schemas: make(map[string]interface{}), types: make(map[string]reflect.Type)}
+}
+
+func (g *schemaGenerator) schemaOf(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.schemaOf(t.Elem())
+	case reflect.Struct:
+		g.define(t)
+		return map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": g.schemaOf(t.Elem())}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	}
+	return map[string]interface{}{"type": "integer"}
+}
+
+func (g *schemaGenerator) define(t reflect.Type) {
+	if other, ok := g.types[t.Name()]; ok {
+		if other != t {
+			panic("schema name collision: " + t.String() + " and " + other.String())
+		}
+		return
+	}
+	g.types[t.Name()] = t
+
+	properties := make(map[string]interface{})
+	g.addFields(t, properties)
+	g.schemas[t.Name()] = map[string]interface{}{"type": "object", "properties": properties}
+}
+
+func (g *schemaGenerator) addFields(t reflect.Type, properties map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			g.addFields(field.Type, properties)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema := g.schemaOf(field.Type)
+		// pointers that are not omitted serialize as null
+		if field.Type.Kind() == reflect.Ptr && !strings.Contains(opts, "omitempty") {
+			if _, ok := schema["$ref"]; ok {
+				schema = map[string]interface{}{"allOf": []interface{}{schema}}
+			}
+			schema["nullable"] = true
+		}
+		properties[name] = schema
+	}
+}
+
+// normalize round trips v through JSON so that it compares equal to a decoded document
+func normalize(v interface{}) interface{} {
+	b, _ := json.Marshal(v)
+	var n interface{}
+	json.Unmarshal(b, &n)
+	return n
+}
+
+// stripDescriptions removes the prose a document adds to its schemas
+func stripDescriptions(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		stripped := make(map[string]interface{})
+		for k, e := range v {
+			if k == "description" || k == "enum" {
+				continue
+			}
+			stripped[k] = stripDescriptions(e)
+		}
+		return stripped
+	case []interface{}:
+		stripped := make([]interface{}, 0, len(v))
+		for _, e := range v {
+			stripped = append(stripped, stripDescriptions(e))
+		}
+		return stripped
+	}
+	return v
+}
+
+var _ = Describe("Describing the API with OpenAPI", Label("OpenAPI"), func() {
+	When("We load the document", func() {
+		It("should be a valid OpenAPI document", func(ctx SpecContext) {
+			doc, _, err := loadOpenAPI(openAPISpec)
+			Expect(err).To(BeNil())
+			Expect(doc.Paths.Find("/schedule")).NotTo(BeNil())
+		})
+
+		It("should describe the types the APIs serialize", func(ctx SpecContext) {
+			// types served by the APIs, their fields' types are described in turn
+			g := newSchemaGenerator()
+			for _, v := range []interface{}{
+				ScheduleResponse{},
+				ScheduleErrorResponse{},
+				ScheduleChangesResponse{},
+				SeasonResponse{},
+				PostseasonResponse{},
+				TeamScheduleResponse{},
+				MatchupResponse{},
+				StandingsResponse{},
+				VenuesResponse{},
+				VenueScheduleResponse{},
+				SubscriptionRequest{},
+				Subscription{},
+				SubscriptionsResponse{},
+				DeadLettersResponse{},
+				ExportGame{},
+			} {
+				g.schemaOf(reflect.TypeOf(v))
+			}
+			Expect(g.types).To(HaveKeyWithValue("Game", reflect.TypeOf(models.Game{})))
+
+			var doc struct {
+				Components struct {
+					Schemas map[string]struct {
+						Type       string                 `json:"type"`
+						Properties map[string]interface{} `json:"properties"`
+					} `json:"schemas"`
+				} `json:"components"`
+			}
+			Expect(json.Unmarshal(openAPISpec, &doc)).To(Succeed())
+
+			for name := range doc.Components.Schemas {
+				Expect(g.schemas).To(HaveKey(name), "schema %s does not describe a served type", name)
+			}
+			for name, generated := range g.schemas {
+				Expect(doc.Components.Schemas).To(HaveKey(name), "%s is not described, add it to openapi.json", name)
+				schema := doc.Components.Schemas[name]
+				Expect(schema.Type).To(Equal("object"))
+				Expect(stripDescriptions(schema.Properties)).To(Equal(normalize(generated.(map[string]interface{})["properties"])),
+					"the properties of schema %s do not match %s", name, g.types[name])
+			}
+		})
+	})
+
+	When("We validate requests", func() {
+		var router *gin.Engine
+
+		BeforeEach(func() {
+			var teamResp models.TeamsResponse
+			setLock = new(sync.RWMutex)
+			err := json.Unmarshal([]byte(teamsAPIJSON), &teamResp)
+			if err != nil {
+				os.Exit(1)
+			}
+			createTeamsSet(teamResp)
+
+			router = gin.New()
+			v1 := router.Group("/api/v1")
+			v1.Use(RequestValidation())
+			ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+			v1.GET("/schedule", ok)
+			v1.GET("/standings", ok)
+			v1.GET("/teams/:id/schedule", ok)
+			v1.GET("/openapi.json", GetOpenAPI)
+			v1.GET("/undocumented", ok)
+			v1.POST("/subscriptions", func(c *gin.Context) {
+				var req SubscriptionRequest
+				Expect(c.ShouldBindJSON(&req)).To(Succeed())
+				c.JSON(http.StatusCreated, req)
+			})
+		})
+
+		do := func(method string, url string, body string) (int, ScheduleErrorResponse) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(method, url, strings.NewReader(body))
+			if body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			router.ServeHTTP(w, req)
+			var resp ScheduleErrorResponse
+			json.Unmarshal(w.Body.Bytes(), &resp)
+			return w.Code, resp
+		}
+
+		It("should pass valid requests", func(ctx SpecContext) {
+			for _, url := range []string{
+				"/api/v1/schedule?teamId=147&date=2021-09-11&limit=10&format=csv",
+				"/api/v1/schedule?teamId=147&view=compact&fields=venue.name",
+				"/api/v1/schedule?team=NYY&date=opening-day",
+				"/api/v1/standings?date=2021-09-11",
+				"/api/v1/teams/147/schedule?season=2021",
+				"/api/v1/openapi.json",
+				"/api/v1/undocumented?anything=goes",
+			} {
+				code, _ := do(http.MethodGet, url, "")
+				Expect(code).To(Equal(http.StatusOK), url)
+			}
+
+			code, _ := do(http.MethodPost, "/api/v1/subscriptions", `{"teamId": 147, "events": ["gameFinal"], "callbackUrl": "https://example.com/hook"}`)
+			Expect(code).To(Equal(http.StatusCreated))
+		})
+
+		It("should reject requests that do not match the document", func(ctx SpecContext) {
+			for url, message := range map[string]string{
+				"/api/v1/schedule?teamId=NYY":            "invalid query parameter teamId",
+				"/api/v1/schedule?teamId=147&limit=0":    "invalid query parameter limit",
+				"/api/v1/schedule?format=xlsx":           "invalid query parameter format",
+				"/api/v1/standings":                      "invalid query parameter date: is required",
+				"/api/v1/standings?date=09/11/2021":      "invalid query parameter date",
+				"/api/v1/teams/NYY/schedule?season=2021": "invalid path parameter id",
+				"/api/v1/teams/147/schedule":             "invalid query parameter season: is required",
+			} {
+				code, resp := do(http.MethodGet, url, "")
+				Expect(code).To(Equal(http.StatusBadRequest), url)
+				Expect(resp.Message).To(HavePrefix(message), url)
+			}
+
+			for _, body := range []string{
+				`{"events": ["gameFinal"], "callbackUrl": "https://example.com/hook"}`,
+				`{"teamId": 147, "events": ["gameStarted"], "callbackUrl": "https://example.com/hook"}`,
+				`{"teamId": "147", "events": ["gameFinal"], "callbackUrl": "https://example.com/hook"}`,
+			} {
+				code, resp := do(http.MethodPost, "/api/v1/subscriptions", body)
+				Expect(code).To(Equal(http.StatusBadRequest), body)
+				Expect(resp.Message).To(HavePrefix("invalid request body"), body)
+			}
+		})
+	})
+})
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+const maxPageLimit = 500
+
+// pageCursor marks where the next page of ordered games begins, it is keyed by
+// the date and gamePk of the last game served so that pages stay stable when
+// games are added or removed. The fields games are ordered by are kept too, so
+// that a page resumes after the last game's position once it has been removed
+type pageCursor struct {
+	Date  string     `json:"d"`
+	After int        `json:"a"`
+	Last  cursorGame `json:"l"`
+}
+
+// cursorGame holds the fields of the last game served that games are ordered by
+type cursorGame struct {
+	GameDate         string `json:"t,omitempty"`
+	AbstractGameCode string `json:"s,omitempty"`
+	Venue            string `json:"v,omitempty"`
+	HomeID           int    `json:"h,omitempty"`
+	AwayID           int    `json:"w,omitempty"`
+}
+
+func newPageCursor(date string, g models.Game) *pageCursor {
+	return &pageCursor{
+		Date:  date,
+		After: g.GamePk,
+		Last: cursorGame{
+			GameDate:         g.GameDate,
+			AbstractGameCode: g.Status.AbstractGameCode,
+			Venue:            g.Venue.Name,
+			HomeID:           g.Teams.Home.Team.ID,
+			AwayID:           g.Teams.Away.Team.ID,
+		},
+	}
+}
+
+// last rebuilds as much of the last game served as games are ordered by
+func (c pageCursor) last() models.Game {
+	var g models.Game
+	g.GamePk = c.After
+	g.GameDate = c.Last.GameDate
+	g.Status.AbstractGameCode = c.Last.AbstractGameCode
+	g.Venue.Name = c.Last.Venue
+	g.Teams.Home.Team.ID = c.Last.HomeID
+	g.Teams.Away.Team.ID = c.Last.AwayID
+	return g
+}
+
+// byGamePk breaks the ties left by other comparators
+func byGamePk(a, b models.Game) bool {
+	return a.GamePk < b.GamePk
+}
+
+// orderedAfter reports whether game b is listed after game a when games are
+// ordered by less, then by first pitch as statsapi lists them
+func orderedAfter(less gameComparator) gameComparator {
+	if less == nil {
+		return chainComparators(byFirstPitch, byGamePk)
+	}
+	return chainComparators(less, byFirstPitch, byGamePk)
+}
+
+func encodeCursor(cursor pageCursor) string {
+	b, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// parsePageParameters validates the limit=<n> and cursor=<cursor> query
+// parameters, a limit of 0 returns every game. date is the single date a
+// cursor must belong to, it is empty for APIs spanning many dates
+func parsePageParameters(limitParam string, cursorParam string, date string) (int, *pageCursor, error) {
+	limit := 0
+	if limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil || limit < 1 || limit > maxPageLimit {
+			return 0, nil, fmt.Errorf("limit must be between 1 and %d", maxPageLimit)
+		}
+	}
+	if cursorParam == "" {
+		return limit, nil, nil
+	}
+	if limit == 0 {
+		return 0, nil, errors.New("cursor requires a limit")
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(cursorParam)
+	if err != nil {
+		return 0, nil, errors.New("invalid cursor")
+	}
+	var cursor pageCursor
+	err = json.Unmarshal(b, &cursor)
+	if err != nil || cursor.After < 1 {
+		return 0, nil, errors.New("invalid cursor")
+	}
+	if date != "" && cursor.Date != date {
+		return 0, nil, errors.New("cursor does not belong to the requested date")
+	}
+	return limit, &cursor, nil
+}
+
+// paginateGames returns a page of a date's games, ordered by less after the
+// requested team's, and the cursor to the next page, if any. The requested
+// team's games are listed first and are all on the first page, even when there
+// are more of them than limit
+func paginateGames(id int, date string, games []models.Game, limit int, cursor *pageCursor, less gameComparator) ([]models.Game, *pageCursor) {
+	if limit == 0 {
+		return games, nil
+	}
+
+	start := 0
+	if cursor != nil {
+		start = -1
+		for i, g := range games {
+			if g.GamePk == cursor.After {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			// the last game served has been removed, resume with the first
+			// of the other teams' games ordered after it
+			after := orderedAfter(less)
+			last := cursor.last()
+			start = len(games)
+			for i, g := range games {
+				if g.Teams.Home.Team.ID != id && g.Teams.Away.Team.ID != id && after(last, g) {
+					start = i
+					break
+				}
+			}
+		}
+	}
+
+	end := start + limit
+	if start == 0 {
+		teamGames := 0
+		for _, g := range games {
+			if g.Teams.Home.Team.ID != id && g.Teams.Away.Team.ID != id {
+				break
+			}
+			teamGames++
+		}
+		if teamGames > end {
+			end = teamGames
+		}
+	}
+	if end >= len(games) {
+		return games[start:], nil
+	}
+	return games[start:end], newPageCursor(date, games[end-1])
+}
+
+// paginateDates returns a page of the games across dates, ordered by date then
+// first pitch, and the cursor to the next page, if any. Dates without a game on
+// the page are left out
+func paginateDates(dates []models.Date, limit int, cursor *pageCursor) ([]models.Date, *pageCursor) {
+	if limit == 0 {
+		return dates, nil
+	}
+
+	type datedGame struct {
+		date int
+		game models.Game
+	}
+	games := make([]datedGame, 0)
+	for i, d := range dates {
+		for _, g := range d.Games {
+			games = append(games, datedGame{date: i, game: g})
+		}
+	}
+
+	start := 0
+	if cursor != nil {
+		start = -1
+		for i, g := range games {
+			if dates[g.date].Date == cursor.Date && g.game.GamePk == cursor.After {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			// the last game served has been removed, resume with the first
+			// game ordered after it
+			after := orderedAfter(nil)
+			last := cursor.last()
+			start = len(games)
+			for i, g := range games {
+				date := dates[g.date].Date
+				if date > cursor.Date || (date == cursor.Date && after(last, g.game)) {
+					start = i
+					break
+				}
+			}
+		}
+	}
+
+	end := start + limit
+	var next *pageCursor
+	if end < len(games) {
+		last := games[end-1]
+		next = newPageCursor(dates[last.date].Date, last.game)
+	} else {
+		end = len(games)
+	}
+
+	page := make([]models.Date, 0)
+	for i, g := range games[start:end] {
+		if i == 0 || games[start+i-1].date != g.date {
+			d := dates[g.date]
+			d.Games = make([]models.Game, 0)
+			page = append(page, d)
+		}
+		page[len(page)-1].Games = append(page[len(page)-1].Games, g.game)
+	}
+	return page, next
+}
+
+// onPage reports whether a game is listed on a page of dates, games are told
+// apart by their start time as well as their gamePk as a suspended game is
+// listed on the date it resumes too
+func onPage(page []models.Date) func(g models.Game) bool {
+	listed := make(map[string]bool)
+	for _, d := range page {
+		for _, g := range d.Games {
+			listed[g.GameDate+"-"+strconv.Itoa(g.GamePk)] = true
+		}
+	}
+	return func(g models.Game) bool {
+		return listed[g.GameDate+"-"+strconv.Itoa(g.GamePk)]
+	}
+}
+
+// nextLink is the request's URL with its cursor replaced by the next page's
+func nextLink(c *gin.Context, cursor pageCursor) string {
+	u := *c.Request.URL
+	query := u.Query()
+	query.Set("cursor", encodeCursor(cursor))
+	u.RawQuery = query.Encode()
+	return u.RequestURI()
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+var _ = Describe("Paginating schedule games", Label("Pagination"), func() {
+	var games []models.Game
+
+	BeforeEach(func() {
+		games = []models.Game{
+			// requested team's double header
+			newTestGame(1, "2021-09-11T17:05:00Z", 147, 111, "F", "Fenway Park"),
+			newTestGame(2, "2021-09-11T23:05:00Z", 147, 111, "P", "Fenway Park"),
+			newTestGame(3, "2021-09-11T17:05:00Z", 141, 110, "F", "Oriole Park at Camden Yards"),
+			newTestGame(4, "2021-09-11T20:10:00Z", 121, 143, "L", "Citizens Bank Park"),
+			newTestGame(5, "2021-09-11T23:05:00Z", 117, 136, "P", "T-Mobile Park"),
+		}
+	})
+
+	When("We do not set a limit", func() {
+		It("should return every game", func(ctx SpecContext) {
+			page, next := paginateGames(147, "2021-09-11", games, 0, nil, nil)
+			Expect(gamePks(page)).To(Equal([]int{1, 2, 3, 4, 5}))
+			Expect(next).To(BeNil())
+		})
+	})
+
+	When("We page through the games", func() {
+		It("should return each game once, in order", func(ctx SpecContext) {
+			page, next := paginateGames(147, "2021-09-11", games, 2, nil, nil)
+			Expect(gamePks(page)).To(Equal([]int{1, 2}))
+			Expect(next).NotTo(BeNil())
+
+			page, next = paginateGames(147, "2021-09-11", games, 2, next, nil)
+			Expect(gamePks(page)).To(Equal([]int{3, 4}))
+
+			page, next = paginateGames(147, "2021-09-11", games, 2, next, nil)
+			Expect(gamePks(page)).To(Equal([]int{5}))
+			Expect(next).To(BeNil())
+		})
+
+		It("should list all of the requested team's games on the first page", func(ctx SpecContext) {
+			page, next := paginateGames(147, "2021-09-11", games, 1, nil, nil)
+			Expect(gamePks(page)).To(Equal([]int{1, 2}))
+
+			page, _ = paginateGames(147, "2021-09-11", games, 1, next, nil)
+			Expect(gamePks(page)).To(Equal([]int{3}))
+		})
+
+		It("should stay stable when an earlier game is removed", func(ctx SpecContext) {
+			_, next := paginateGames(147, "2021-09-11", games, 3, nil, nil)
+			// game 1 is postponed and dropped between requests
+			page, _ := paginateGames(147, "2021-09-11", games[1:], 3, next, nil)
+			Expect(gamePks(page)).To(Equal([]int{4, 5}))
+		})
+
+		It("should resume after the last game served once it has been removed", func(ctx SpecContext) {
+			_, next := paginateGames(147, "2021-09-11", games, 3, nil, nil)
+			Expect(next.After).To(Equal(3))
+			// game 3 is postponed and dropped between requests
+			remaining := []models.Game{games[0], games[1], games[3], games[4]}
+			page, _ := paginateGames(147, "2021-09-11", remaining, 3, next, nil)
+			Expect(gamePks(page)).To(Equal([]int{4, 5}))
+		})
+
+		It("should resume after the removed game's position in the requested order", func(ctx SpecContext) {
+			// by venue the other games are Citizens Bank Park, Oriole Park and T-Mobile Park
+			ordered, err := orderGames(147, append([]models.Game{}, games...), byVenue)
+			Expect(err).To(BeNil())
+			Expect(gamePks(ordered)).To(Equal([]int{1, 2, 4, 3, 5}))
+			_, next := paginateGames(147, "2021-09-11", ordered, 3, nil, byVenue)
+			Expect(next.After).To(Equal(4))
+
+			remaining := []models.Game{ordered[0], ordered[1], ordered[3], ordered[4]}
+			page, _ := paginateGames(147, "2021-09-11", remaining, 3, next, byVenue)
+			Expect(gamePks(page)).To(Equal([]int{3, 5}))
+		})
+	})
+
+	When("We page games across dates", func() {
+		var dates []models.Date
+
+		BeforeEach(func() {
+			dates = []models.Date{
+				{Date: "2021-09-10", Games: games[:2]},
+				{Date: "2021-09-11", Games: []models.Game{}},
+				{Date: "2021-09-12", Games: games[2:]},
+			}
+		})
+
+		It("should return every date without a limit", func(ctx SpecContext) {
+			page, next := paginateDates(dates, 0, nil)
+			Expect(page).To(Equal(dates))
+			Expect(next).To(BeNil())
+		})
+
+		It("should walk the games in date order", func(ctx SpecContext) {
+			page, next := paginateDates(dates, 3, nil)
+			Expect(page).To(HaveLen(2))
+			Expect(page[0].Date).To(Equal("2021-09-10"))
+			Expect(gamePks(page[0].Games)).To(Equal([]int{1, 2}))
+			Expect(page[1].Date).To(Equal("2021-09-12"))
+			Expect(gamePks(page[1].Games)).To(Equal([]int{3}))
+			Expect(next.Date).To(Equal("2021-09-12"))
+			Expect(next.After).To(Equal(3))
+
+			page, next = paginateDates(dates, 3, next)
+			Expect(page).To(HaveLen(1))
+			Expect(gamePks(page[0].Games)).To(Equal([]int{4, 5}))
+			Expect(next).To(BeNil())
+		})
+
+		It("should only resume after a game on the cursor's date", func(ctx SpecContext) {
+			// the same game listed on two dates, as a suspended game is
+			dates[1].Games = []models.Game{games[0]}
+			_, next := paginateDates(dates, 3, nil)
+			Expect(next.Date).To(Equal("2021-09-11"))
+			Expect(next.After).To(Equal(1))
+
+			page, _ := paginateDates(dates, 3, next)
+			Expect(gamePks(page[0].Games)).To(Equal([]int{3, 4, 5}))
+		})
+
+		It("should resume after the last game served once it has been removed", func(ctx SpecContext) {
+			_, next := paginateDates(dates, 3, nil)
+			Expect(next.After).To(Equal(3))
+			// game 3 is postponed and dropped between requests
+			dates[2].Games = games[3:]
+			page, next := paginateDates(dates, 3, next)
+			Expect(page).To(HaveLen(1))
+			Expect(page[0].Date).To(Equal("2021-09-12"))
+			Expect(gamePks(page[0].Games)).To(Equal([]int{4, 5}))
+			Expect(next).To(BeNil())
+		})
+
+		It("should report the games listed on a page", func(ctx SpecContext) {
+			page, _ := paginateDates(dates, 2, nil)
+			listed := onPage(page)
+			Expect(listed(games[1])).To(BeTrue())
+			Expect(listed(games[2])).To(BeFalse())
+		})
+	})
+
+	When("We parse the page parameters", func() {
+		It("should round trip a cursor", func(ctx SpecContext) {
+			limit, cursor, err := parsePageParameters("2", encodeCursor(pageCursor{Date: "2021-09-11", After: 2, Last: cursorGame{GameDate: "2021-09-11T23:05:00Z"}}), "2021-09-11")
+			Expect(err).To(BeNil())
+			Expect(limit).To(Equal(2))
+			Expect(*cursor).To(Equal(pageCursor{Date: "2021-09-11", After: 2, Last: cursorGame{GameDate: "2021-09-11T23:05:00Z"}}))
+		})
+
+		It("should reject invalid limits and cursors", func(ctx SpecContext) {
+			for _, limit := range []string{"0", "-1", "501", "ten"} {
+				_, _, err := parsePageParameters(limit, "", "2021-09-11")
+				Expect(err).NotTo(BeNil())
+			}
+			_, _, err := parsePageParameters("2", "not-a-cursor", "2021-09-11")
+			Expect(err).To(MatchError("invalid cursor"))
+			_, _, err = parsePageParameters("2", encodeCursor(pageCursor{Date: "2021-09-12", After: 2, Last: cursorGame{GameDate: "2021-09-11T23:05:00Z"}}), "2021-09-11")
+			Expect(err).NotTo(BeNil())
+
+			// APIs spanning many dates accept a cursor from any date
+			_, cursor, err := parsePageParameters("2", encodeCursor(pageCursor{Date: "2021-09-12", After: 2, Last: cursorGame{GameDate: "2021-09-11T23:05:00Z"}}), "")
+			Expect(err).To(BeNil())
+			Expect(cursor.Date).To(Equal("2021-09-12"))
+		})
+	})
+
+	When("We link to the next page", func() {
+		It("should keep the request's other parameters", func(ctx SpecContext) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/api/v1/schedule?teamId=147&date=2021-09-11&limit=2", nil)
+			link, err := url.Parse(nextLink(c, pageCursor{Date: "2021-09-11", After: 2, Last: cursorGame{GameDate: "2021-09-11T23:05:00Z"}}))
+			Expect(err).To(BeNil())
+			Expect(link.Path).To(Equal("/api/v1/schedule"))
+			Expect(link.Query().Get("teamId")).To(Equal("147"))
+			Expect(link.Query().Get("limit")).To(Equal("2"))
+			Expect(link.Query().Get("cursor")).NotTo(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+	"github.com/stefanKnott/mlbtakehome/pkg/store"
+)
+
+// scheduleStore persists fetched schedules and the team registry, it is
+// optional and nil when the service runs without persistent storage
+var scheduleStore store.ScheduleStore
+
+// SetScheduleStore configures the store populated by schedule fetches and
+// consulted before statsapi for dates whose games are all final
+func SetScheduleStore(s store.ScheduleStore) {
+	scheduleStore = s
+}
+
+// allFinal reports whether every game has been completed, in which case
+// the date's schedule will no longer change
+func allFinal(games []models.Game) bool {
+	if len(games) == 0 {
+		return false
+	}
+	for _, g := range games {
+		if g.Status.AbstractGameCode != "F" {
+			return false
+		}
+	}
+	return true
+}
+
+// persistSchedule stores every date of an upstream schedule response
+func persistSchedule(schedResp *models.ScheduleResponse) {
+	if scheduleStore == nil {
+		return
+	}
+
+	for _, d := range schedResp.Dates {
+		err := scheduleStore.PutDate(d)
+		if err != nil {
+			fmt.Printf("got err when storing schedule for %s: %s\n", d.Date, err.Error())
+		}
+	}
+}
+
+// getStoredDate returns a date's stored schedule when all of its games are final
+func getStoredDate(date string) (*models.ScheduleResponse, bool) {
+	if scheduleStore == nil {
+		return nil, false
+	}
+
+	d, ok, err := scheduleStore.GetDate(date)
+	if err != nil {
+		fmt.Printf("got err when reading stored schedule for %s: %s\n", date, err.Error())
+		return nil, false
+	}
+	if !ok || !allFinal(d.Games) {
+		return nil, false
+	}
+
+	return &models.ScheduleResponse{
+		TotalItems: d.TotalItems,
+		TotalGames: d.TotalGames,
+		Dates:      []models.Date{d},
+	}, true
+}
+
+// getDateSchedule returns a single date's schedule, from the store when all
+// of its games are final and otherwise from statsapi
+func getDateSchedule(date string) (*models.ScheduleResponse, error) {
+	schedResp, ok := getStoredDate(date)
+	if ok {
+		return schedResp, nil
+	}
+	return getRecordedScheduleAPIResp(fmt.Sprintf(scheuldeAPIFmtStr, date))
+}
+
+// getDateRangeSchedule returns the games keep accepts, every game when nil,
+// between two YYYY-MM-DD dates, inclusive. Dates stored with every game final, or stored as an off
+// day, are read from the store and the span of the remaining dates is fetched
+// with fetch, so that a stored season is served without statsapi
+func getDateRangeSchedule(startDate string, endDate string, keep func(models.Game) bool, fetch func(startDate string, endDate string) (*models.ScheduleResponse, error)) (*models.ScheduleResponse, error) {
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return nil, errors.New("invalid startDate string")
+	}
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return nil, errors.New("invalid endDate string")
+	}
+	if scheduleStore == nil {
+		return fetch(startDate, endDate)
+	}
+
+	stored := make(map[string]models.Date)
+	firstMissing, lastMissing := "", ""
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		d, ok, err := scheduleStore.GetDate(date)
+		if err != nil {
+			fmt.Printf("got err when reading stored schedule for %s: %s\n", date, err.Error())
+		}
+		if err == nil && ok && (len(d.Games) == 0 || allFinal(d.Games)) {
+			stored[date] = d
+			continue
+		}
+		if firstMissing == "" {
+			firstMissing = date
+		}
+		lastMissing = date
+	}
+
+	dates := make([]models.Date, 0)
+	keepDate := func(d models.Date) {
+		games := make([]models.Game, 0, len(d.Games))
+		for _, g := range d.Games {
+			if keep == nil || keep(g) {
+				games = append(games, g)
+			}
+		}
+		// statsapi omits dates without games
+		if len(games) > 0 {
+			d.Games = games
+			d.TotalGames = uint8(len(games))
+			d.TotalItems = d.TotalGames
+			dates = append(dates, d)
+		}
+	}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		if firstMissing != "" && date == firstMissing {
+			schedResp, err := fetch(firstMissing, lastMissing)
+			if err != nil {
+				return nil, err
+			}
+			for _, d := range schedResp.Dates {
+				keepDate(d)
+			}
+		}
+		if d, ok := stored[date]; ok && (firstMissing == "" || date < firstMissing || date > lastMissing) {
+			keepDate(d)
+		}
+	}
+
+	return &models.ScheduleResponse{Dates: dates}, nil
+}
+
+// getStoredVenue returns a venue referenced by a stored game
+func getStoredVenue(id int) (models.Venue, bool) {
+	if scheduleStore == nil {
+		return models.Venue{}, false
+	}
+
+	venue, ok, err := scheduleStore.GetVenue(id)
+	if err != nil {
+		fmt.Printf("got err when reading stored venue %d: %s\n", id, err.Error())
+		return models.Venue{}, false
+	}
+	return venue, ok
+}
+
+// persistTeams stores the team registry so that it can be restored when the
+// teams API is unavailable
+func persistTeams(teamsResp models.TeamsResponse) {
+	if scheduleStore == nil {
+		return
+	}
+
+	err := scheduleStore.PutTeams(teamsResp.Teams)
+	if err != nil {
+		fmt.Printf("got err when storing teams: %s\n", err.Error())
+	}
+}
+
+// loadStoredTeams populates the team registry from the store if it has not
+// yet been populated from the teams API
+func loadStoredTeams() {
+	if scheduleStore == nil {
+		return
+	}
+
+	setLock.RLock()
+	populated := teamSet != nil
+	setLock.RUnlock()
+	if populated {
+		return
+	}
+
+	teams, err := scheduleStore.ListTeams()
+	if err != nil {
+		fmt.Printf("got err when reading stored teams: %s\n", err.Error())
+		return
+	}
+	if len(teams) > 0 {
+		createTeamsSet(models.TeamsResponse{Teams: teams})
+	}
+}
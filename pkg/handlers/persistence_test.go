@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+	"github.com/stefanKnott/mlbtakehome/pkg/store"
+)
+
+var _ = Describe("Serving schedules from the store", Label("Persistence"), func() {
+	BeforeEach(func() {
+		s, err := store.NewBoltStore(filepath.Join(GinkgoT().TempDir(), "test.db"))
+		Expect(err).To(BeNil())
+		SetScheduleStore(s)
+	})
+
+	AfterEach(func() {
+		Expect(scheduleStore.Close()).To(Succeed())
+		SetScheduleStore(nil)
+	})
+
+	When("Every game on a stored date is final", func() {
+		It("should be served from the store", func(ctx SpecContext) {
+			persistSchedule(&models.ScheduleResponse{Dates: []models.Date{{
+				Date:  "2021-09-11",
+				Games: []models.Game{newTestGame(1, "2021-09-11T23:05:00Z", 147, 111, "F", "Fenway Park")},
+			}}})
+
+			schedResp, ok := getStoredDate("2021-09-11")
+			Expect(ok).To(BeTrue())
+			Expect(schedResp.Dates).To(HaveLen(1))
+			Expect(gamePks(schedResp.Dates[0].Games)).To(Equal([]int{1}))
+		})
+	})
+
+	When("A stored date has games that are not final", func() {
+		It("should not be served from the store", func(ctx SpecContext) {
+			persistSchedule(&models.ScheduleResponse{Dates: []models.Date{{
+				Date: "2021-09-11",
+				Games: []models.Game{
+					newTestGame(1, "2021-09-11T17:05:00Z", 147, 111, "F", "Fenway Park"),
+					newTestGame(2, "2021-09-11T23:05:00Z", 141, 110, "L", "Oriole Park at Camden Yards"),
+				},
+			}}})
+
+			_, ok := getStoredDate("2021-09-11")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	When("We read a range of dates", func() {
+		var fetched [][2]string
+		fetch := func(startDate string, endDate string) (*models.ScheduleResponse, error) {
+			fetched = append(fetched, [2]string{startDate, endDate})
+			return &models.ScheduleResponse{Dates: []models.Date{{
+				Date:  "2021-09-13",
+				Games: []models.Game{newTestGame(4, "2021-09-13T23:05:00Z", 147, 111, "P", "Fenway Park")},
+			}}}, nil
+		}
+
+		BeforeEach(func() {
+			fetched = nil
+			fenway := newTestGame(1, "2021-09-11T17:05:00Z", 147, 111, "F", "Fenway Park")
+			fenway.Venue.ID = 3
+			camden := newTestGame(2, "2021-09-11T23:05:00Z", 141, 110, "F", "Oriole Park at Camden Yards")
+			camden.Venue.ID = 2
+			persistSchedule(&models.ScheduleResponse{Dates: []models.Date{
+				{Date: "2021-09-11", Games: []models.Game{fenway, camden}},
+				// an off day
+				{Date: "2021-09-12", Games: []models.Game{}},
+			}})
+		})
+
+		It("should read the dates stored once final without fetching them", func(ctx SpecContext) {
+			home := func(g models.Game) bool { return g.Teams.Home.Team.ID == 111 }
+			schedResp, err := getDateRangeSchedule("2021-09-11", "2021-09-12", home, fetch)
+			Expect(err).To(BeNil())
+			Expect(fetched).To(BeEmpty())
+			Expect(schedResp.Dates).To(HaveLen(1))
+			Expect(gamePks(schedResp.Dates[0].Games)).To(Equal([]int{1}))
+		})
+
+		It("should fetch the span of the dates that are not stored", func(ctx SpecContext) {
+			schedResp, err := getDateRangeSchedule("2021-09-11", "2021-09-13", nil, fetch)
+			Expect(err).To(BeNil())
+			Expect(fetched).To(Equal([][2]string{{"2021-09-13", "2021-09-13"}}))
+			Expect(schedResp.Dates).To(HaveLen(2))
+			Expect(gamePks(schedResp.Dates[0].Games)).To(Equal([]int{1, 2}))
+			Expect(gamePks(schedResp.Dates[1].Games)).To(Equal([]int{4}))
+		})
+
+		It("should serve a stored venue's schedule while statsapi is unavailable", func(ctx SpecContext) {
+			var teamResp models.TeamsResponse
+			setLock = new(sync.RWMutex)
+			Expect(json.Unmarshal([]byte(teamsAPIJSON), &teamResp)).To(Succeed())
+			createTeamsSet(teamResp)
+			getScheduleAPIResp = func(url string) (*models.ScheduleResponse, error) {
+				return nil, errors.New("statsapi is unavailable")
+			}
+			DeferCleanup(func() {
+				getScheduleAPIResp = fetchScheduleAPIResp
+			})
+
+			router := gin.New()
+			router.GET("/venues/:id/schedule", GetVenueSchedule)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/venues/3/schedule?startDate=2021-09-11&endDate=2021-09-12", nil))
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var resp VenueScheduleResponse
+			Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+			Expect(resp.Venue.Name).To(Equal("Fenway Park"))
+			Expect(resp.Dates).To(HaveLen(1))
+			Expect(gamePks(resp.Dates[0].Games)).To(Equal([]int{1}))
+
+			// the venue is named without any games in the range
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/venues/3/schedule?startDate=2021-09-12&endDate=2021-09-12", nil))
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+			Expect(resp.Venue.Name).To(Equal("Fenway Park"))
+			Expect(resp.Dates).To(BeEmpty())
+		})
+
+		It("should serve a stored team's season while statsapi is unavailable", func(ctx SpecContext) {
+			var teamResp models.TeamsResponse
+			setLock = new(sync.RWMutex)
+			Expect(json.Unmarshal([]byte(teamsAPIJSON), &teamResp)).To(Succeed())
+			createTeamsSet(teamResp)
+			getScheduleAPIResp = func(url string) (*models.ScheduleResponse, error) {
+				return nil, errors.New("statsapi is unavailable")
+			}
+			seasonCache.lock.Lock()
+			seasonCache.seasons[2021] = models.Season{SeasonID: "2021", SeasonStartDate: "2021-09-11", SeasonEndDate: "2021-09-12"}
+			seasonCache.lock.Unlock()
+			DeferCleanup(func() {
+				getScheduleAPIResp = fetchScheduleAPIResp
+				seasonCache.lock.Lock()
+				seasonCache.seasons = make(map[int]models.Season)
+				seasonCache.lock.Unlock()
+			})
+
+			router := gin.New()
+			router.GET("/teams/:id/schedule", GetTeamSchedule)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/teams/110/schedule?season=2021&gameType=", nil))
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+
+			var resp TeamScheduleResponse
+			Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+			Expect(resp.Series).To(HaveLen(1))
+			Expect(resp.Series[0].Games).To(HaveLen(1))
+			Expect(resp.Series[0].Games[0].GamePk).To(Equal(2))
+		})
+	})
+})
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+const (
+	homeTeam = "home"
+	awayTeam = "away"
+
+	resultWin  = "W"
+	resultLoss = "L"
+	resultTie  = "T"
+)
+
+// newPerspective describes game g from the point of view of team id, returning
+// nil if the team is neither home nor away
+func newPerspective(id int, g models.Game) *models.Perspective {
+	var myTeam, opponent models.ScheduleTeam
+	var homeAway string
+	switch id {
+	case g.Teams.Home.Team.ID:
+		myTeam, opponent, homeAway = g.Teams.Home, g.Teams.Away, homeTeam
+	case g.Teams.Away.Team.ID:
+		myTeam, opponent, homeAway = g.Teams.Away, g.Teams.Home, awayTeam
+	default:
+		return nil
+	}
+
+	perspective := &models.Perspective{
+		Opponent:      opponent.Team,
+		HomeAway:      homeAway,
+		Score:         myTeam.Score,
+		OpponentScore: opponent.Score,
+		Record:        myTeam.LeagueRecord,
+	}
+
+	// only completed games have a result, postponed games are final without a winner
+	if g.Status.AbstractGameCode == "F" {
+		switch {
+		case g.IsTie:
+			perspective.Result = resultTie
+		case myTeam.IsWinner:
+			perspective.Result = resultWin
+		case opponent.IsWinner:
+			perspective.Result = resultLoss
+		}
+	}
+	return perspective
+}
+
+// gameCompleted reports whether a game was played to completion, a suspended
+// game is also listed on its original date without having been completed
+func gameCompleted(g models.Game) bool {
+	if g.Status.AbstractGameCode != "F" {
+		return false
+	}
+	for _, state := range []string{"Final", "Game Over", "Completed Early"} {
+		if strings.HasPrefix(g.Status.DetailedState, state) {
+			return true
+		}
+	}
+	return false
+}
+
+// addPerspectives populates the perspective of team id on each of its games
+func addPerspectives(id int, games []models.Game) {
+	for i := range games {
+		games[i].Perspective = newPerspective(id, games[i])
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+var _ = Describe("Describing games from the requested team's perspective", Label("Perspective"), func() {
+	When("The requested team won on the road", func() {
+		game := newTestGame(1, "2021-09-11T23:05:00Z", 147, 111, "F", "Fenway Park")
+		game.Teams.Away.Team.Name = "New York Yankees"
+		game.Teams.Home.Team.Name = "Boston Red Sox"
+		game.Teams.Away.Score = 5
+		game.Teams.Home.Score = 3
+		game.Teams.Away.IsWinner = true
+		game.Teams.Away.LeagueRecord = models.LeagueRecord{Wins: 80, Losses: 62, Pct: ".563"}
+
+		It("should describe an away win against the home team", func(ctx SpecContext) {
+			perspective := newPerspective(147, game)
+			Expect(perspective).ToNot(BeNil())
+			Expect(perspective.Opponent.Name).To(Equal("Boston Red Sox"))
+			Expect(perspective.HomeAway).To(Equal(awayTeam))
+			Expect(perspective.Score).To(Equal(uint8(5)))
+			Expect(perspective.OpponentScore).To(Equal(uint8(3)))
+			Expect(perspective.Result).To(Equal(resultWin))
+			Expect(perspective.Record.Wins).To(Equal(uint8(80)))
+		})
+
+		It("should describe a home loss for the opponent", func(ctx SpecContext) {
+			perspective := newPerspective(111, game)
+			Expect(perspective.HomeAway).To(Equal(homeTeam))
+			Expect(perspective.Result).To(Equal(resultLoss))
+		})
+	})
+
+	When("The game has not been completed", func() {
+		game := newTestGame(1, "2021-09-11T23:05:00Z", 147, 111, "L", "Fenway Park")
+
+		It("should not have a result", func(ctx SpecContext) {
+			Expect(newPerspective(147, game).Result).To(BeEmpty())
+		})
+	})
+
+	When("The game ended in a tie", func() {
+		game := newTestGame(1, "2021-09-11T23:05:00Z", 147, 111, "F", "Fenway Park")
+		game.IsTie = true
+
+		It("should be a tie", func(ctx SpecContext) {
+			Expect(newPerspective(147, game).Result).To(Equal(resultTie))
+		})
+	})
+
+	When("The requested team is not playing", func() {
+		game := newTestGame(1, "2021-09-11T23:05:00Z", 147, 111, "F", "Fenway Park")
+
+		It("should not have a perspective", func(ctx SpecContext) {
+			games := []models.Game{game}
+			addPerspectives(141, games)
+			Expect(games[0].Perspective).To(BeNil())
+		})
+	})
+})
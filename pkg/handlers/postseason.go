@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+// structs for /postseason API responses
+type PostseasonResponse struct {
+	Season string             `json:"season"`
+	Series []PostseasonSeries `json:"series"`
+	Next   string             `json:"next,omitempty"`
+}
+
+type PostseasonSeries struct {
+	GameType          string                 `json:"gameType"`
+	SeriesDescription string                 `json:"seriesDescription"`
+	GamesInSeries     uint8                  `json:"gamesInSeries"`
+	Teams             []PostseasonSeriesTeam `json:"teams"`
+	Games             []PostseasonGame       `json:"games"`
+}
+
+type PostseasonSeriesTeam struct {
+	Team models.Team `json:"team"`
+	Wins uint8       `json:"wins"`
+}
+
+type PostseasonGame struct {
+	models.Game
+	IsIfNecessary bool `json:"isIfNecessary"`
+}
+
+// seriesKey identifies a postseason series by its round and the two clubs
+// playing in it, regardless of which club is home
+func seriesKey(g models.Game) string {
+	home, away := g.Teams.Home.Team.ID, g.Teams.Away.Team.ID
+	if home > away {
+		home, away = away, home
+	}
+	return fmt.Sprintf("%s-%s-%d-%d", g.GameType, g.SeriesDescription, home, away)
+}
+
+func roundIndex(gameType string) int {
+	for i, round := range postseasonRounds {
+		if round == gameType {
+			return i
+		}
+	}
+	return len(postseasonRounds)
+}
+
+// groupPostseasonSeries groups postseason games into their series, ordering
+// series by round and then by first pitch of their opening game, and games
+// within a series by their game number
+func groupPostseasonSeries(games []models.Game) []PostseasonSeries {
+	seriesByKey := make(map[string]*PostseasonSeries)
+	keys := make([]string, 0)
+	for _, g := range games {
+		key := seriesKey(g)
+		series, ok := seriesByKey[key]
+		if !ok {
+			series = &PostseasonSeries{
+				GameType:          g.GameType,
+				SeriesDescription: g.SeriesDescription,
+				GamesInSeries:     g.GamesInSeries,
+				Teams: []PostseasonSeriesTeam{
+					{Team: g.Teams.Away.Team},
+					{Team: g.Teams.Home.Team},
+				},
+				Games: make([]PostseasonGame, 0),
+			}
+			seriesByKey[key] = series
+			keys = append(keys, key)
+		}
+
+		for i := range series.Teams {
+			if series.Teams[i].Team.ID == g.Teams.Home.Team.ID && g.Teams.Home.IsWinner {
+				series.Teams[i].Wins++
+			}
+			if series.Teams[i].Team.ID == g.Teams.Away.Team.ID && g.Teams.Away.IsWinner {
+				series.Teams[i].Wins++
+			}
+		}
+		series.Games = append(series.Games, PostseasonGame{Game: g, IsIfNecessary: g.IfNecessary == "Y"})
+	}
+
+	allSeries := make([]PostseasonSeries, 0, len(keys))
+	for _, key := range keys {
+		series := seriesByKey[key]
+		sort.SliceStable(series.Games, func(i, j int) bool {
+			return series.Games[i].SeriesGameNumber < series.Games[j].SeriesGameNumber
+		})
+		allSeries = append(allSeries, *series)
+	}
+
+	sort.SliceStable(allSeries, func(i, j int) bool {
+		ri, rj := roundIndex(allSeries[i].GameType), roundIndex(allSeries[j].GameType)
+		if ri != rj {
+			return ri < rj
+		}
+		return byFirstPitch(allSeries[i].Games[0].Game, allSeries[j].Games[0].Game)
+	})
+	return allSeries
+}
+
+// pagePostseasonSeries keeps the games of each series listed on a page,
+// leaving out the series without any
+func pagePostseasonSeries(series []PostseasonSeries, listed func(g models.Game) bool) []PostseasonSeries {
+	paged := make([]PostseasonSeries, 0)
+	for _, s := range series {
+		games := make([]PostseasonGame, 0)
+		for _, g := range s.Games {
+			if listed(g.Game) {
+				games = append(games, g)
+			}
+		}
+		if len(games) == 0 {
+			continue
+		}
+		s.Games = games
+		paged = append(paged, s)
+	}
+	return paged
+}
+
+func validateSeason(season string) error {
+	_, err := time.Parse("2006", season)
+	if err != nil {
+		return errors.New("invalid season string")
+	}
+	return nil
+}
+
+// getPostseasonSchedule returns a season's postseason games, read from the
+// store for the dates stored once final when the postseason's dates are known
+func getPostseasonSchedule(season string) (*models.ScheduleResponse, error) {
+	year, err := strconv.Atoi(season)
+	if err != nil || scheduleStore == nil {
+		return getScheduleAPIResp(fmt.Sprintf(postseasonAPIFmtStr, season))
+	}
+	s, err := getSeason(year)
+	if err != nil || s.PostSeasonStartDate == "" || s.PostSeasonEndDate == "" {
+		return getScheduleAPIResp(fmt.Sprintf(postseasonAPIFmtStr, season))
+	}
+
+	rounds := make(map[string]bool)
+	for _, round := range postseasonRounds {
+		rounds[round] = true
+	}
+	postseason := func(g models.Game) bool {
+		return rounds[g.GameType]
+	}
+	return getDateRangeSchedule(s.PostSeasonStartDate, s.PostSeasonEndDate, postseason, func(startDate string, endDate string) (*models.ScheduleResponse, error) {
+		return getScheduleAPIResp(fmt.Sprintf(postseasonRangeAPIFmtStr, startDate, endDate))
+	})
+}
+
+// GetPostseason serves the /postseason?season=<YYYY> API which allows a client
+// to receive the season's playoff games grouped by series, an optional
+// limit=<n> pages the games across dates
+func GetPostseason(c *gin.Context) {
+	format, err := negotiateFormat(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	season := c.Query("season")
+	err = validateSeason(season)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	limit, cursor, err := parsePageParameters(c.Query("limit"), c.Query("cursor"), "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	schedResp, err := getPostseasonSchedule(season)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	gameTypes := map[string]bool{}
+	for _, round := range postseasonRounds {
+		gameTypes[round] = true
+	}
+	games := make([]models.Game, 0)
+	for i := range schedResp.Dates {
+		schedResp.Dates[i].Games = filterGameTypes(gameTypes, schedResp.Dates[i].Games)
+		games = append(games, schedResp.Dates[i].Games...)
+	}
+
+	// pages follow the date order of games
+	page, next := paginateDates(schedResp.Dates, limit, cursor)
+	if format != formatJSON {
+		// exports carry the next page's link in a header, as they have no envelope
+		if next != nil {
+			c.Header("Link", fmt.Sprintf("<%s>; rel=\"next\"", nextLink(c, *next)))
+		}
+		writeGames(c, format, page)
+		return
+	}
+
+	// series wins are tallied over every game, then trimmed to the page
+	resp := PostseasonResponse{Season: season, Series: pagePostseasonSeries(groupPostseasonSeries(games), onPage(page))}
+	if next != nil {
+		resp.Next = nextLink(c, *next)
+	}
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+func newTestPostseasonGame(gamePk int, gameType string, gameDate string, awayId int, homeId int, seriesGameNumber uint8, homeWins bool, ifNecessary string) models.Game {
+	g := newTestGame(gamePk, gameDate, awayId, homeId, "F", "")
+	g.GameType = gameType
+	g.SeriesGameNumber = seriesGameNumber
+	g.GamesInSeries = 7
+	g.IfNecessary = ifNecessary
+	g.Teams.Home.IsWinner = homeWins
+	g.Teams.Away.IsWinner = !homeWins
+	if ifNecessary == "Y" {
+		g.Status.AbstractGameCode = "P"
+		g.Teams.Home.IsWinner = false
+		g.Teams.Away.IsWinner = false
+	}
+	return g
+}
+
+var _ = Describe("Processing Postseason requests", Label("Postseason"), func() {
+	When("We parse the gameType query parameter", func() {
+		Context("and it contains several valid game types", func() {
+			It("should return the set of game types", func(ctx SpecContext) {
+				gameTypes, err := parseGameTypes("R,f, D")
+				Expect(err).To(BeNil())
+				Expect(gameTypes).To(Equal(map[string]bool{"R": true, "F": true, "D": true}))
+			})
+		})
+		Context("and it contains game types statsapi uses outside of MLB's modern postseason", func() {
+			It("should accept them", func(ctx SpecContext) {
+				gameTypes, err := parseGameTypes("C,P,I")
+				Expect(err).To(BeNil())
+				Expect(gameTypes).To(Equal(map[string]bool{"C": true, "P": true, "I": true}))
+			})
+		})
+		Context("and it contains an unknown game type", func() {
+			It("should return an error", func(ctx SpecContext) {
+				_, err := parseGameTypes("R,Q")
+				Expect(err).ToNot(BeNil())
+			})
+		})
+	})
+
+	When("We filter a Games slice by game type", func() {
+		game1 := newTestGame(1, "2021-03-01T18:05:00Z", 147, 141, "P", "")
+		game1.GameType = springTrainingGameType
+		game2 := newTestGame(2, "2021-03-01T18:10:00Z", 111, 147, "P", "")
+		game2.GameType = exhibitionGameType
+		game3 := newTestGame(3, "2021-04-01T18:10:00Z", 111, 147, "P", "")
+		game3.GameType = regularSeasonGameType
+
+		It("should only return games of the requested types", func(ctx SpecContext) {
+			filtered := filterGameTypes(map[string]bool{"S": true, "R": true}, []models.Game{game1, game2, game3})
+			Expect(gamePks(filtered)).To(Equal([]int{1, 3}))
+		})
+	})
+
+	When("We group postseason games by series", func() {
+		games := []models.Game{
+			newTestPostseasonGame(5, worldSeriesGameType, "2021-10-27T00:09:00Z", 144, 117, 1, false, "N"),
+			newTestPostseasonGame(3, divisionSeriesGameType, "2021-10-09T00:07:00Z", 121, 119, 2, true, "N"),
+			newTestPostseasonGame(2, divisionSeriesGameType, "2021-10-08T00:07:00Z", 121, 119, 1, false, "N"),
+			newTestPostseasonGame(4, divisionSeriesGameType, "2021-10-10T00:07:00Z", 119, 121, 3, true, "N"),
+			newTestPostseasonGame(6, worldSeriesGameType, "2021-10-28T00:09:00Z", 117, 144, 7, false, "Y"),
+			newTestPostseasonGame(1, wildCardGameType, "2021-10-06T00:08:00Z", 119, 137, 1, false, "N"),
+		}
+
+		It("should order series by round and games by their number within the series", func(ctx SpecContext) {
+			series := groupPostseasonSeries(games)
+			Expect(series).To(HaveLen(3))
+
+			Expect(series[0].GameType).To(Equal(wildCardGameType))
+			Expect(series[1].GameType).To(Equal(divisionSeriesGameType))
+			Expect(series[1].Games).To(HaveLen(3))
+			Expect(series[1].Games[0].GamePk).To(Equal(2))
+			Expect(series[1].Games[1].GamePk).To(Equal(3))
+			Expect(series[1].Games[2].GamePk).To(Equal(4))
+			Expect(series[2].GameType).To(Equal(worldSeriesGameType))
+		})
+
+		It("should tally series wins and flag if necessary games", func(ctx SpecContext) {
+			series := groupPostseasonSeries(games)
+
+			Expect(series[1].Teams[0].Team.ID).To(Equal(121))
+			Expect(series[1].Teams[0].Wins).To(Equal(uint8(2)))
+			Expect(series[1].Teams[1].Wins).To(Equal(uint8(1)))
+			Expect(series[2].Games[0].IsIfNecessary).To(BeFalse())
+			Expect(series[2].Games[1].IsIfNecessary).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,469 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+const (
+	scoreboardSubscribe   = "subscribe"
+	scoreboardUnsubscribe = "unsubscribe"
+
+	scoreboardSnapshot = "snapshot"
+	scoreboardUpdate   = "update"
+	scoreboardError    = "error"
+
+	// maxScoreboardDates bounds the dates a connection may subscribe to, as
+	// each date polls statsapi
+	maxScoreboardDates = 7
+
+	scoreboardSendBuffer = 64
+	scoreboardWriteWait  = 10 * time.Second
+	scoreboardPongWait   = 60 * time.Second
+	scoreboardPingPeriod = (scoreboardPongWait * 9) / 10
+
+	// scoreboardMaxMessageSize bounds the requests a client may send, larger
+	// frames close the connection
+	scoreboardMaxMessageSize = 4096
+)
+
+var scoreboardPollRate = 15 * time.Second
+
+// the scoreboard is a read only public feed, so connections are accepted from any origin
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// structs for /ws messages
+type ScoreboardRequest struct {
+	Action  string   `json:"action"`
+	TeamIDs []int    `json:"teamIds"`
+	Dates   []string `json:"dates"`
+}
+
+type ScoreboardMessage struct {
+	Type    string       `json:"type"`
+	Date    string       `json:"date,omitempty"`
+	Games   []GameUpdate `json:"games,omitempty"`
+	Message string       `json:"message,omitempty"`
+}
+
+// GameUpdate carries the state of a game that changed since the last poll
+type GameUpdate struct {
+	GamePk           int    `json:"gamePk"`
+	AbstractGameCode string `json:"abstractGameCode"`
+	DetailedState    string `json:"detailedState"`
+	AwayTeamID       int    `json:"awayTeamId"`
+	AwayScore        uint8  `json:"awayScore"`
+	HomeTeamID       int    `json:"homeTeamId"`
+	HomeScore        uint8  `json:"homeScore"`
+}
+
+func newGameUpdate(g models.Game) GameUpdate {
+	return GameUpdate{
+		GamePk:           g.GamePk,
+		AbstractGameCode: g.Status.AbstractGameCode,
+		DetailedState:    g.Status.DetailedState,
+		AwayTeamID:       g.Teams.Away.Team.ID,
+		AwayScore:        g.Teams.Away.Score,
+		HomeTeamID:       g.Teams.Home.Team.ID,
+		HomeScore:        g.Teams.Home.Score,
+	}
+}
+
+// scoreboardClient is a single websocket connection and the team IDs and
+// dates it is subscribed to
+type scoreboardClient struct {
+	conn  *websocket.Conn
+	send  chan []byte
+	teams map[int]bool
+	dates map[string]bool
+}
+
+// wants reports whether an update on date is relevant to the client
+func (c *scoreboardClient) wants(date string, update GameUpdate) bool {
+	return c.dates[date] || c.teams[update.AwayTeamID] || c.teams[update.HomeTeamID]
+}
+
+// datePoller polls a single date's schedule on behalf of every client
+type datePoller struct {
+	stop chan struct{}
+	// games holds the last state seen of each game, nil until the first poll
+	games map[int]GameUpdate
+}
+
+// final reports whether every game on the poller's date was final when last polled
+func (p *datePoller) final() bool {
+	if p.games == nil {
+		return false
+	}
+	for _, update := range p.games {
+		if update.AbstractGameCode != "F" {
+			return false
+		}
+	}
+	return true
+}
+
+// scoreboardHub fans out game updates from one poller per date to every
+// subscribed client, so that many clients do not cause many upstream calls
+type scoreboardHub struct {
+	lock     sync.Mutex
+	clients  map[*scoreboardClient]bool
+	pollers  map[string]*datePoller
+	fetch    func(date string) ([]models.Game, error)
+	today    func() string
+	pollRate time.Duration
+}
+
+var scoreboard = newScoreboardHub(fetchScoreboardGames, TodayDate, scoreboardPollRate)
+
+func newScoreboardHub(fetch func(date string) ([]models.Game, error), today func() string, pollRate time.Duration) *scoreboardHub {
+	return &scoreboardHub{
+		clients:  make(map[*scoreboardClient]bool),
+		pollers:  make(map[string]*datePoller),
+		fetch:    fetch,
+		today:    today,
+		pollRate: pollRate,
+	}
+}
+
+func fetchScoreboardGames(date string) ([]models.Game, error) {
+	schedResp, err := getDateSchedule(date)
+	if err != nil {
+		return nil, err
+	}
+
+	games := make([]models.Game, 0)
+	for _, d := range schedResp.Dates {
+		games = append(games, d.Games...)
+	}
+	return games, nil
+}
+
+func (h *scoreboardHub) register(client *scoreboardClient) {
+	h.lock.Lock()
+	h.clients[client] = true
+	h.lock.Unlock()
+}
+
+func (h *scoreboardHub) unregister(client *scoreboardClient) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.clients[client] {
+		delete(h.clients, client)
+		close(client.send)
+	}
+	h.reconcilePollers()
+}
+
+// handle applies a subscribe or unsubscribe request to a client, sending it
+// the last known state of any newly subscribed games
+func (h *scoreboardHub) handle(client *scoreboardClient, req ScoreboardRequest) error {
+	for _, date := range req.Dates {
+		_, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			return fmt.Errorf("invalid date string: %s", date)
+		}
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	switch req.Action {
+	case scoreboardSubscribe:
+		subscribed := len(client.dates)
+		for _, date := range req.Dates {
+			if !client.dates[date] {
+				subscribed++
+			}
+		}
+		if subscribed > maxScoreboardDates {
+			return fmt.Errorf("at most %d dates may be subscribed to per connection", maxScoreboardDates)
+		}
+
+		for _, id := range req.TeamIDs {
+			client.teams[id] = true
+		}
+		for _, date := range req.Dates {
+			client.dates[date] = true
+		}
+	case scoreboardUnsubscribe:
+		for _, id := range req.TeamIDs {
+			delete(client.teams, id)
+		}
+		for _, date := range req.Dates {
+			delete(client.dates, date)
+		}
+	default:
+		return fmt.Errorf("invalid action: %s", req.Action)
+	}
+	h.reconcilePollers()
+
+	if req.Action == scoreboardSubscribe {
+		for date, poller := range h.pollers {
+			if _, ok := h.clients[client]; !ok {
+				// disconnected by sendTo as too slow to keep up
+				return nil
+			}
+			if poller.games == nil {
+				continue
+			}
+			updates := make([]GameUpdate, 0, len(poller.games))
+			for _, update := range poller.games {
+				updates = append(updates, update)
+			}
+			h.sendTo(client, scoreboardSnapshot, date, updates)
+		}
+	}
+	return nil
+}
+
+// reconcilePollers starts a poller for every date a client is interested in and
+// stops pollers no client is interested in, callers must hold the lock. It is
+// run on every poll as well as on every subscription change, so that team
+// subscriptions move on to the next day's games after midnight, while still
+// following the previous day's games until they are final, ie. a late west
+// coast game that runs past midnight
+func (h *scoreboardHub) reconcilePollers() {
+	today := h.today()
+	yesterday := today
+	if day, err := time.Parse("2006-01-02", today); err == nil {
+		yesterday = day.AddDate(0, 0, -1).Format("2006-01-02")
+	}
+
+	wanted := make(map[string]bool)
+	for client := range h.clients {
+		for date := range client.dates {
+			wanted[date] = true
+		}
+		if len(client.teams) > 0 {
+			// team subscriptions follow the team's games today
+			wanted[today] = true
+			if poller, ok := h.pollers[yesterday]; ok && !poller.final() {
+				wanted[yesterday] = true
+			}
+		}
+	}
+
+	for date, poller := range h.pollers {
+		if !wanted[date] {
+			close(poller.stop)
+			delete(h.pollers, date)
+		}
+	}
+	for date := range wanted {
+		if _, ok := h.pollers[date]; !ok {
+			poller := &datePoller{stop: make(chan struct{})}
+			h.pollers[date] = poller
+			go h.poll(date, poller)
+		}
+	}
+}
+
+func (h *scoreboardHub) poll(date string, poller *datePoller) {
+	ticker := time.NewTicker(h.pollRate)
+	defer ticker.Stop()
+
+	for {
+		games, err := h.fetch(date)
+		if err != nil {
+			fmt.Printf("got err when polling scoreboard: %s\n", err.Error())
+		} else {
+			h.publish(date, poller, games)
+		}
+
+		select {
+		case <-poller.stop:
+			return
+		case <-ticker.C:
+		}
+
+		h.lock.Lock()
+		h.reconcilePollers()
+		stopped := h.pollers[date] != poller
+		h.lock.Unlock()
+		if stopped {
+			return
+		}
+	}
+}
+
+// publish records the latest state of a date's games and broadcasts the games
+// whose status or score changed since the last poll
+func (h *scoreboardHub) publish(date string, poller *datePoller, games []models.Game) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.pollers[date] != poller {
+		// the poller was stopped while fetching
+		return
+	}
+
+	messageType := scoreboardUpdate
+	if poller.games == nil {
+		messageType = scoreboardSnapshot
+		poller.games = make(map[int]GameUpdate)
+	}
+
+	updates := make([]GameUpdate, 0)
+	for _, g := range games {
+		update := newGameUpdate(g)
+		if last, ok := poller.games[g.GamePk]; ok && last == update {
+			continue
+		}
+		poller.games[g.GamePk] = update
+		updates = append(updates, update)
+	}
+	if len(updates) == 0 {
+		return
+	}
+
+	for client := range h.clients {
+		h.sendTo(client, messageType, date, updates)
+	}
+}
+
+// sendTo queues the updates relevant to a client, a client too slow to keep up
+// is disconnected rather than blocking every other client, callers must hold the lock
+func (h *scoreboardHub) sendTo(client *scoreboardClient, messageType string, date string, updates []GameUpdate) {
+	if _, ok := h.clients[client]; !ok {
+		// already disconnected, its send channel is closed
+		return
+	}
+
+	relevant := make([]GameUpdate, 0)
+	for _, update := range updates {
+		if client.wants(date, update) {
+			relevant = append(relevant, update)
+		}
+	}
+	if len(relevant) == 0 {
+		return
+	}
+	sort.Slice(relevant, func(i, j int) bool {
+		return relevant[i].GamePk < relevant[j].GamePk
+	})
+
+	b, err := json.Marshal(ScoreboardMessage{Type: messageType, Date: date, Games: relevant})
+	if err != nil {
+		return
+	}
+	select {
+	case client.send <- b:
+	default:
+		delete(h.clients, client)
+		close(client.send)
+	}
+}
+
+func (h *scoreboardHub) readPump(client *scoreboardClient) {
+	defer func() {
+		h.unregister(client)
+		client.conn.Close()
+	}()
+
+	client.conn.SetReadLimit(scoreboardMaxMessageSize)
+	client.conn.SetReadDeadline(time.Now().Add(scoreboardPongWait))
+	client.conn.SetPongHandler(func(string) error {
+		return client.conn.SetReadDeadline(time.Now().Add(scoreboardPongWait))
+	})
+
+	for {
+		var req ScoreboardRequest
+		err := client.conn.ReadJSON(&req)
+		if err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				h.sendError(client, "invalid message")
+				continue
+			}
+			return
+		}
+
+		err = h.handle(client, req)
+		if err != nil {
+			h.sendError(client, err.Error())
+		}
+	}
+}
+
+func (h *scoreboardHub) sendError(client *scoreboardClient, message string) {
+	b, err := json.Marshal(ScoreboardMessage{Type: scoreboardError, Message: message})
+	if err != nil {
+		return
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if !h.clients[client] {
+		return
+	}
+	select {
+	case client.send <- b:
+	default:
+	}
+}
+
+func (h *scoreboardHub) writePump(client *scoreboardClient) {
+	ticker := time.NewTicker(scoreboardPingPeriod)
+	defer func() {
+		ticker.Stop()
+		client.conn.Close()
+	}()
+
+	for {
+		select {
+		case b, ok := <-client.send:
+			client.conn.SetWriteDeadline(time.Now().Add(scoreboardWriteWait))
+			if !ok {
+				client.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := client.conn.WriteMessage(websocket.TextMessage, b); err != nil {
+				return
+			}
+		case <-ticker.C:
+			client.conn.SetWriteDeadline(time.Now().Add(scoreboardWriteWait))
+			if err := client.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (h *scoreboardHub) serveWs(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader has already responded to the client
+		return
+	}
+
+	client := &scoreboardClient{
+		conn:  conn,
+		send:  make(chan []byte, scoreboardSendBuffer),
+		teams: make(map[int]bool),
+		dates: make(map[string]bool),
+	}
+	h.register(client)
+	go h.writePump(client)
+	go h.readPump(client)
+}
+
+// GetScoreboardSocket serves the /ws API which upgrades to a websocket over
+// which clients subscribe to team IDs or dates and receive game status and
+// score updates as they happen
+func GetScoreboardSocket(c *gin.Context) {
+	scoreboard.serveWs(c.Writer, c.Request)
+}
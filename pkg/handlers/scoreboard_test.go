@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+// fakeSchedule serves games per date in place of statsapi
+type fakeSchedule struct {
+	lock  sync.Mutex
+	games map[string][]models.Game
+}
+
+func (f *fakeSchedule) fetch(date string) ([]models.Game, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]models.Game{}, f.games[date]...), nil
+}
+
+func (f *fakeSchedule) set(date string, games ...models.Game) {
+	f.lock.Lock()
+	f.games[date] = games
+	f.lock.Unlock()
+}
+
+var _ = Describe("Streaming scoreboard updates", Label("Scoreboard"), func() {
+	var schedule *fakeSchedule
+	var hub *scoreboardHub
+	var server *httptest.Server
+
+	dial := func() *websocket.Conn {
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+		Expect(err).To(BeNil())
+		return conn
+	}
+	receive := func(conn *websocket.Conn) ScoreboardMessage {
+		var msg ScoreboardMessage
+		conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		Expect(conn.ReadJSON(&msg)).To(Succeed())
+		return msg
+	}
+
+	BeforeEach(func() {
+		schedule = &fakeSchedule{games: make(map[string][]models.Game)}
+		schedule.set("2021-09-11",
+			newTestGame(1, "2021-09-11T17:05:00Z", 147, 111, "P", "Fenway Park"),
+			newTestGame(2, "2021-09-11T17:05:00Z", 141, 110, "P", "Oriole Park at Camden Yards"),
+		)
+		schedule.set("2021-09-12", newTestGame(3, "2021-09-12T17:05:00Z", 147, 111, "P", "Fenway Park"))
+
+		hub = newScoreboardHub(schedule.fetch, func() string { return "2021-09-12" }, 20*time.Millisecond)
+		server = httptest.NewServer(http.HandlerFunc(hub.serveWs))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("A client subscribes to a date", func() {
+		It("should receive a snapshot and then only the games that changed", func(ctx SpecContext) {
+			conn := dial()
+			defer conn.Close()
+			Expect(conn.WriteJSON(ScoreboardRequest{Action: scoreboardSubscribe, Dates: []string{"2021-09-11"}})).To(Succeed())
+
+			msg := receive(conn)
+			Expect(msg.Type).To(Equal(scoreboardSnapshot))
+			Expect(msg.Games).To(HaveLen(2))
+
+			live := newTestGame(2, "2021-09-11T17:05:00Z", 141, 110, "L", "Oriole Park at Camden Yards")
+			live.Teams.Away.Score = 1
+			schedule.set("2021-09-11", newTestGame(1, "2021-09-11T17:05:00Z", 147, 111, "P", "Fenway Park"), live)
+
+			msg = receive(conn)
+			Expect(msg.Type).To(Equal(scoreboardUpdate))
+			Expect(msg.Games).To(Equal([]GameUpdate{newGameUpdate(live)}))
+		})
+	})
+
+	When("A client subscribes to a team", func() {
+		It("should only receive that team's games today", func(ctx SpecContext) {
+			conn := dial()
+			defer conn.Close()
+			Expect(conn.WriteJSON(ScoreboardRequest{Action: scoreboardSubscribe, TeamIDs: []int{147}})).To(Succeed())
+
+			msg := receive(conn)
+			Expect(msg.Date).To(Equal("2021-09-12"))
+			Expect(msg.Games).To(HaveLen(1))
+			Expect(msg.Games[0].GamePk).To(Equal(3))
+		})
+
+		It("should follow the team's games on to the next day after midnight, once the previous day's are final", func(ctx SpecContext) {
+			var lock sync.Mutex
+			today := "2021-09-11"
+			hub = newScoreboardHub(schedule.fetch, func() string {
+				lock.Lock()
+				defer lock.Unlock()
+				return today
+			}, 20*time.Millisecond)
+			server.Close()
+			server = httptest.NewServer(http.HandlerFunc(hub.serveWs))
+
+			conn := dial()
+			defer conn.Close()
+			Expect(conn.WriteJSON(ScoreboardRequest{Action: scoreboardSubscribe, TeamIDs: []int{147}})).To(Succeed())
+			Expect(receive(conn).Date).To(Equal("2021-09-11"))
+
+			lock.Lock()
+			today = "2021-09-12"
+			lock.Unlock()
+
+			msg := receive(conn)
+			Expect(msg.Date).To(Equal("2021-09-12"))
+			Expect(msg.Games[0].GamePk).To(Equal(3))
+			polled := func() []string {
+				hub.lock.Lock()
+				defer hub.lock.Unlock()
+				dates := make([]string, 0)
+				for date := range hub.pollers {
+					dates = append(dates, date)
+				}
+				sort.Strings(dates)
+				return dates
+			}
+			Consistently(polled, 100*time.Millisecond).Should(Equal([]string{"2021-09-11", "2021-09-12"}))
+
+			// the previous day's late game goes final after midnight
+			final := newTestGame(1, "2021-09-11T17:05:00Z", 147, 111, "F", "Fenway Park")
+			schedule.set("2021-09-11", final, newTestGame(2, "2021-09-11T17:05:00Z", 141, 110, "F", "Oriole Park at Camden Yards"))
+			msg = receive(conn)
+			Expect(msg.Date).To(Equal("2021-09-11"))
+			Expect(msg.Games).To(Equal([]GameUpdate{newGameUpdate(final)}))
+			Eventually(polled).Should(Equal([]string{"2021-09-12"}))
+		})
+	})
+
+	When("A client subscribes to too many dates", func() {
+		It("should receive an error and not poll them", func(ctx SpecContext) {
+			conn := dial()
+			defer conn.Close()
+			dates := make([]string, 0)
+			for day := 12 - maxScoreboardDates; day <= 12; day++ {
+				dates = append(dates, fmt.Sprintf("2021-09-%02d", day))
+			}
+			Expect(conn.WriteJSON(ScoreboardRequest{Action: scoreboardSubscribe, Dates: dates})).To(Succeed())
+
+			msg := receive(conn)
+			Expect(msg.Type).To(Equal(scoreboardError))
+			Expect(msg.Message).To(ContainSubstring("at most"))
+			hub.lock.Lock()
+			Expect(hub.pollers).To(BeEmpty())
+			hub.lock.Unlock()
+
+			Expect(conn.WriteJSON(ScoreboardRequest{Action: scoreboardSubscribe, Dates: dates[1:]})).To(Succeed())
+			Expect(receive(conn).Type).To(Equal(scoreboardSnapshot))
+		})
+	})
+
+	When("Many clients subscribe to the same date", func() {
+		It("should share a single poller", func(ctx SpecContext) {
+			conns := make([]*websocket.Conn, 0)
+			for i := 0; i < 10; i++ {
+				conn := dial()
+				defer conn.Close()
+				Expect(conn.WriteJSON(ScoreboardRequest{Action: scoreboardSubscribe, Dates: []string{"2021-09-11"}})).To(Succeed())
+				conns = append(conns, conn)
+			}
+			for _, conn := range conns {
+				Expect(receive(conn).Type).To(Equal(scoreboardSnapshot))
+			}
+
+			hub.lock.Lock()
+			Expect(hub.pollers).To(HaveLen(1))
+			Expect(hub.clients).To(HaveLen(10))
+			hub.lock.Unlock()
+		})
+	})
+
+	When("A client is too slow to receive its snapshots", func() {
+		It("should be disconnected without sending to it again", func(ctx SpecContext) {
+			// nothing reads from an unbuffered send channel
+			client := &scoreboardClient{send: make(chan []byte), teams: make(map[int]bool), dates: make(map[string]bool)}
+			hub.register(client)
+			hub.lock.Lock()
+			for _, date := range []string{"2021-09-11", "2021-09-12"} {
+				games := make(map[int]GameUpdate)
+				for _, g := range schedule.games[date] {
+					games[g.GamePk] = newGameUpdate(g)
+				}
+				hub.pollers[date] = &datePoller{stop: make(chan struct{}), games: games}
+			}
+			hub.lock.Unlock()
+
+			Expect(hub.handle(client, ScoreboardRequest{Action: scoreboardSubscribe, Dates: []string{"2021-09-11", "2021-09-12"}})).To(Succeed())
+			hub.lock.Lock()
+			Expect(hub.clients).NotTo(HaveKey(client))
+			hub.lock.Unlock()
+		})
+	})
+
+	When("A client sends a request larger than the read limit", func() {
+		It("should be disconnected", func(ctx SpecContext) {
+			conn := dial()
+			defer conn.Close()
+			teamIDs := make([]int, scoreboardMaxMessageSize)
+			Expect(conn.WriteJSON(ScoreboardRequest{Action: scoreboardSubscribe, TeamIDs: teamIDs})).To(Succeed())
+
+			var msg ScoreboardMessage
+			conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+			err := conn.ReadJSON(&msg)
+			Expect(websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseMessageTooBig, websocket.CloseNoStatusReceived)).To(BeTrue(), fmt.Sprint(err))
+		})
+	})
+
+	When("A client sends an invalid request", func() {
+		It("should receive an error", func(ctx SpecContext) {
+			conn := dial()
+			defer conn.Close()
+			Expect(conn.WriteJSON(ScoreboardRequest{Action: "watch"})).To(Succeed())
+
+			msg := receive(conn)
+			Expect(msg.Type).To(Equal(scoreboardError))
+		})
+	})
+})
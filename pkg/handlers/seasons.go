@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+var errSeasonNotFound = errors.New("season not found")
+
+// seasonCache holds the seasons fetched from statsapi, a season's calendar is
+// published well ahead of opening day and rarely changes
+var seasonCache = struct {
+	lock    sync.RWMutex
+	seasons map[int]models.Season
+}{seasons: make(map[int]models.Season)}
+
+// structs for /seasons API responses
+type SeasonResponse struct {
+	Season         string     `json:"season"`
+	SpringTraining DateWindow `json:"springTraining"`
+	RegularSeason  DateWindow `json:"regularSeason"`
+	AllStarGame    string     `json:"allStarGame"`
+	Postseason     DateWindow `json:"postseason"`
+}
+
+// DateWindow is an inclusive range of YYYY-MM-DD dates
+type DateWindow struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// OffSeason describes the next season for a date on which no games are played
+// because it falls outside of a season
+type OffSeason struct {
+	NextSeason          string `json:"nextSeason"`
+	SpringTrainingStart string `json:"springTrainingStart"`
+	OpeningDay          string `json:"openingDay"`
+}
+
+func newSeasonResponse(s models.Season) SeasonResponse {
+	return SeasonResponse{
+		Season:         s.SeasonID,
+		SpringTraining: DateWindow{Start: s.SpringStartDate, End: s.SpringEndDate},
+		RegularSeason:  DateWindow{Start: s.RegularSeasonStartDate, End: s.RegularSeasonEndDate},
+		AllStarGame:    s.AllStarDate,
+		Postseason:     DateWindow{Start: s.PostSeasonStartDate, End: s.PostSeasonEndDate},
+	}
+}
+
+// getSeason returns a season's calendar, fetching it from statsapi on first use
+func getSeason(year int) (models.Season, error) {
+	seasonCache.lock.RLock()
+	season, ok := seasonCache.seasons[year]
+	seasonCache.lock.RUnlock()
+	if ok {
+		return season, nil
+	}
+
+	seasonsResp, err := getSeasonAPIResp(year)
+	if err != nil {
+		return models.Season{}, err
+	}
+	if len(seasonsResp.Seasons) == 0 {
+		return models.Season{}, fmt.Errorf("%w: %d", errSeasonNotFound, year)
+	}
+
+	season = seasonsResp.Seasons[0]
+	seasonCache.lock.Lock()
+	seasonCache.seasons[year] = season
+	seasonCache.lock.Unlock()
+	return season, nil
+}
+
+// validateSeasonDate ensures a YYYY-MM-DD date falls in a year MLB played,
+// dates are not rejected when statsapi's seasons data is unavailable
+func validateSeasonDate(date string) error {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return errors.New("invalid date string")
+	}
+
+	_, err = getSeason(day.Year())
+	if errors.Is(err, errSeasonNotFound) {
+		return fmt.Errorf("no MLB season in %d", day.Year())
+	}
+	return nil
+}
+
+// getOffSeason returns the next season when date falls before spring training
+// or after the postseason, and nil when date is during a season
+func getOffSeason(date string) (*OffSeason, error) {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, errors.New("invalid date string")
+	}
+
+	season, err := getSeason(day.Year())
+	if err != nil {
+		return nil, err
+	}
+	if date >= season.SeasonStartDate && date <= season.SeasonEndDate {
+		return nil, nil
+	}
+
+	if date > season.SeasonEndDate {
+		season, err = getSeason(day.Year() + 1)
+		if errors.Is(err, errSeasonNotFound) {
+			// next season's calendar is not yet published
+			return &OffSeason{NextSeason: strconv.Itoa(day.Year() + 1)}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &OffSeason{NextSeason: season.SeasonID, SpringTrainingStart: season.SpringStartDate, OpeningDay: season.RegularSeasonStartDate}, nil
+}
+
+// currentSeason returns the season under way or, during the off-season, the
+// most recent one
+func currentSeason() int {
+	today := TodayDate()
+	year := clock().In(easternTime()).Year()
+	season, err := getSeason(year)
+	if err == nil && season.SeasonStartDate != "" && today < season.SeasonStartDate {
+		return year - 1
+	}
+	return year
+}
+
+// GetSeason serves the /seasons/<year> API which allows a client to receive a
+// season's key dates
+func GetSeason(c *gin.Context) {
+	season := c.Param("year")
+	err := validateSeason(season)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+	year, _ := strconv.Atoi(season)
+
+	s, err := getSeason(year)
+	if errors.Is(err, errSeasonNotFound) {
+		c.JSON(http.StatusNotFound, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newSeasonResponse(s))
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+var _ = Describe("Season calendars", Label("Seasons"), func() {
+	BeforeEach(func() {
+		seasonCache.lock.Lock()
+		seasonCache.seasons[2021] = models.Season{
+			SeasonID:               "2021",
+			SeasonStartDate:        "2021-01-01",
+			SpringStartDate:        "2021-02-28",
+			SpringEndDate:          "2021-03-30",
+			RegularSeasonStartDate: "2021-04-01",
+			AllStarDate:            "2021-07-13",
+			RegularSeasonEndDate:   "2021-10-03",
+			PostSeasonStartDate:    "2021-10-05",
+			PostSeasonEndDate:      "2021-11-02",
+			SeasonEndDate:          "2021-12-01",
+		}
+		seasonCache.seasons[2022] = models.Season{
+			SeasonID:               "2022",
+			SeasonStartDate:        "2022-02-01",
+			SpringStartDate:        "2022-03-17",
+			RegularSeasonStartDate: "2022-04-07",
+			SeasonEndDate:          "2022-12-01",
+		}
+		seasonCache.lock.Unlock()
+	})
+
+	AfterEach(func() {
+		clock = time.Now
+		seasonCache.lock.Lock()
+		seasonCache.seasons = make(map[int]models.Season)
+		seasonCache.lock.Unlock()
+	})
+
+	When("We request a season", func() {
+		It("should return its key dates", func(ctx SpecContext) {
+			router := gin.New()
+			router.GET("/seasons/:year", GetSeason)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/seasons/2021", nil))
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var resp SeasonResponse
+			Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+			Expect(resp).To(Equal(SeasonResponse{
+				Season:         "2021",
+				SpringTraining: DateWindow{Start: "2021-02-28", End: "2021-03-30"},
+				RegularSeason:  DateWindow{Start: "2021-04-01", End: "2021-10-03"},
+				AllStarGame:    "2021-07-13",
+				Postseason:     DateWindow{Start: "2021-10-05", End: "2021-11-02"},
+			}))
+		})
+
+		It("should reject an invalid year", func(ctx SpecContext) {
+			router := gin.New()
+			router.GET("/seasons/:year", GetSeason)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/seasons/21", nil))
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("A date falls during a season", func() {
+		It("should not be in the off-season", func(ctx SpecContext) {
+			Expect(getOffSeason("2021-09-11")).To(BeNil())
+		})
+	})
+
+	When("A date falls after a season", func() {
+		It("should point to the next season", func(ctx SpecContext) {
+			Expect(getOffSeason("2021-12-15")).To(Equal(&OffSeason{NextSeason: "2022", SpringTrainingStart: "2022-03-17", OpeningDay: "2022-04-07"}))
+		})
+	})
+
+	When("A date falls before a season", func() {
+		It("should point to that season", func(ctx SpecContext) {
+			Expect(getOffSeason("2022-01-15")).To(Equal(&OffSeason{NextSeason: "2022", SpringTrainingStart: "2022-03-17", OpeningDay: "2022-04-07"}))
+		})
+	})
+
+	When("We pick the team registry's season", func() {
+		It("should use the season under way", func(ctx SpecContext) {
+			clock = func() time.Time { return time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC) }
+			Expect(currentSeason()).To(Equal(2022))
+		})
+
+		It("should use the most recent season during the off-season", func(ctx SpecContext) {
+			clock = func() time.Time { return time.Date(2022, 1, 15, 12, 0, 0, 0, time.UTC) }
+			Expect(currentSeason()).To(Equal(2021))
+		})
+	})
+})
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+// gameComparator reports whether game a should be listed before game b
+type gameComparator func(a, b models.Game) bool
+
+// sortStrategies maps the values accepted by the sort query parameter to a
+// comparator, some strategies order relative to the requested team
+var sortStrategies = map[string]func(id int) gameComparator{
+	"time":     func(int) gameComparator { return byFirstPitch },
+	"live":     func(int) gameComparator { return byLive },
+	"division": byDivisionRivals,
+	"league":   byLeagueRivals,
+	"venue":    func(int) gameComparator { return byVenue },
+}
+
+func getTeam(id int) (models.Team, bool) {
+	setLock.RLock()
+	team, ok := teamSet[id]
+	setLock.RUnlock()
+	return team, ok
+}
+
+// byFirstPitch orders games by their scheduled start, games with an
+// unparseable gameDate are listed last
+func byFirstPitch(a, b models.Game) bool {
+	at, aErr := time.Parse(time.RFC3339, a.GameDate)
+	bt, bErr := time.Parse(time.RFC3339, b.GameDate)
+	if aErr != nil || bErr != nil {
+		return aErr == nil && bErr != nil
+	}
+	return at.Before(bt)
+}
+
+// byLive lists in-progress games ahead of everything else
+func byLive(a, b models.Game) bool {
+	return a.Status.AbstractGameCode == "L" && b.Status.AbstractGameCode != "L"
+}
+
+// byVenue groups games played at the same venue, ordered by venue name
+func byVenue(a, b models.Game) bool {
+	return a.Venue.Name < b.Venue.Name
+}
+
+// byDivisionRivals lists games involving a team from the requested team's division first
+func byDivisionRivals(id int) gameComparator {
+	return byRival(id, func(myTeam, other models.Team) bool {
+		return myTeam.Division != nil && other.Division != nil && myTeam.Division.ID == other.Division.ID
+	})
+}
+
+// byLeagueRivals lists games involving a team from the requested team's league first
+func byLeagueRivals(id int) gameComparator {
+	return byRival(id, func(myTeam, other models.Team) bool {
+		return myTeam.League != nil && other.League != nil && myTeam.League.ID == other.League.ID
+	})
+}
+
+func byRival(id int, isRival func(myTeam, other models.Team) bool) gameComparator {
+	myTeam, _ := getTeam(id)
+	involvesRival := func(g models.Game) bool {
+		for _, teamId := range []int{g.Teams.Home.Team.ID, g.Teams.Away.Team.ID} {
+			if other, ok := getTeam(teamId); ok && isRival(myTeam, other) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(a, b models.Game) bool {
+		return involvesRival(a) && !involvesRival(b)
+	}
+}
+
+// chainComparators composes comparators so that later comparators only break
+// ties left by earlier ones
+func chainComparators(comparators ...gameComparator) gameComparator {
+	return func(a, b models.Game) bool {
+		for _, less := range comparators {
+			if less(a, b) {
+				return true
+			}
+			if less(b, a) {
+				return false
+			}
+		}
+		return false
+	}
+}
+
+// parseSortParameter builds a comparator from a comma separated list of sort
+// strategies, ie. sort=live,time, an empty value preserves upstream ordering
+func parseSortParameter(id int, sortParam string) (gameComparator, error) {
+	if sortParam == "" {
+		return nil, nil
+	}
+
+	var comparators []gameComparator
+	for _, strategy := range strings.Split(sortParam, ",") {
+		newComparator, ok := sortStrategies[strings.TrimSpace(strategy)]
+		if !ok {
+			return nil, fmt.Errorf("invalid sort strategy: %s", strategy)
+		}
+		comparators = append(comparators, newComparator(id))
+	}
+
+	return chainComparators(comparators...), nil
+}
+
+// sortGames stably orders games with less, a nil comparator leaves games untouched
+func sortGames(games []models.Game, less gameComparator) {
+	if less == nil {
+		return
+	}
+	sort.SliceStable(games, func(i, j int) bool {
+		return less(games[i], games[j])
+	})
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+func newTestGame(gamePk int, gameDate string, awayId int, homeId int, abstractGameCode string, venue string) models.Game {
+	return models.Game{
+		GamePk:   gamePk,
+		GameDate: gameDate,
+		Status: models.Status{
+			AbstractGameCode: abstractGameCode,
+		},
+		Teams: models.Teams{
+			Away: models.ScheduleTeam{Team: models.Team{ID: awayId}},
+			Home: models.ScheduleTeam{Team: models.Team{ID: homeId}},
+		},
+		Venue: models.Venue{Name: venue},
+	}
+}
+
+func gamePks(games []models.Game) []int {
+	pks := make([]int, 0, len(games))
+	for _, g := range games {
+		pks = append(pks, g.GamePk)
+	}
+	return pks
+}
+
+var _ = Describe("Ordering Schedule games", Label("Sort"), func() {
+	var games []models.Game
+
+	BeforeEach(func() {
+		var teamResp models.TeamsResponse
+		setLock = new(sync.RWMutex)
+		err := json.Unmarshal([]byte(teamsAPIJSON), &teamResp)
+		if err != nil {
+			os.Exit(1)
+		}
+		createTeamsSet(teamResp)
+
+		games = []models.Game{
+			// NYM @ PHI, NL East
+			newTestGame(1, "2021-09-11T23:05:00Z", 121, 143, "P", "Citizens Bank Park"),
+			// BAL @ BOS, AL East
+			newTestGame(2, "2021-09-11T20:10:00Z", 110, 111, "L", "Fenway Park"),
+			// TOR @ NYY, requested team's game
+			newTestGame(3, "2021-09-11T17:05:00Z", 141, 147, "F", "Yankee Stadium"),
+			// HOU @ SEA, AL West
+			newTestGame(4, "2021-09-11T17:05:00Z", 117, 136, "L", "T-Mobile Park"),
+		}
+	})
+
+	When("We parse the sort query parameter", func() {
+		Context("and it is empty", func() {
+			It("should preserve the upstream ordering", func(ctx SpecContext) {
+				less, err := parseSortParameter(147, "")
+				Expect(err).To(BeNil())
+
+				ordered, err := orderGames(147, games, less)
+				Expect(err).To(BeNil())
+				Expect(gamePks(ordered)).To(Equal([]int{3, 1, 2, 4}))
+			})
+		})
+		Context("and it contains an unknown strategy", func() {
+			It("should return an error", func(ctx SpecContext) {
+				_, err := parseSortParameter(147, "time,bogus")
+				Expect(err).ToNot(BeNil())
+			})
+		})
+	})
+
+	When("We order the remaining games", func() {
+		Context("by first pitch", func() {
+			It("should list the requested team's games first, then chronologically", func(ctx SpecContext) {
+				less, err := parseSortParameter(147, "time")
+				Expect(err).To(BeNil())
+
+				ordered, err := orderGames(147, games, less)
+				Expect(err).To(BeNil())
+				Expect(gamePks(ordered)).To(Equal([]int{3, 4, 2, 1}))
+			})
+		})
+		Context("by division rivals", func() {
+			It("should list games involving the requested team's division next", func(ctx SpecContext) {
+				less, err := parseSortParameter(147, "division")
+				Expect(err).To(BeNil())
+
+				ordered, err := orderGames(147, games, less)
+				Expect(err).To(BeNil())
+				Expect(gamePks(ordered)).To(Equal([]int{3, 2, 1, 4}))
+			})
+		})
+		Context("by league rivals, then live, then first pitch", func() {
+			It("should apply each strategy as a tie breaker for the previous", func(ctx SpecContext) {
+				less, err := parseSortParameter(147, "league,live,time")
+				Expect(err).To(BeNil())
+
+				ordered, err := orderGames(147, games, less)
+				Expect(err).To(BeNil())
+				Expect(gamePks(ordered)).To(Equal([]int{3, 4, 2, 1}))
+			})
+		})
+		Context("by venue", func() {
+			It("should order games by venue name", func(ctx SpecContext) {
+				less, err := parseSortParameter(147, "venue")
+				Expect(err).To(BeNil())
+
+				ordered, err := orderGames(147, games, less)
+				Expect(err).To(BeNil())
+				Expect(gamePks(ordered)).To(Equal([]int{3, 1, 2, 4}))
+			})
+		})
+	})
+
+	When("We serialize a schedule team", func() {
+		It("should omit the league and division statsapi does not send", func(ctx SpecContext) {
+			b, err := json.Marshal(games[0].Teams.Home)
+			Expect(err).To(BeNil())
+			Expect(string(b)).NotTo(ContainSubstring(`"league"`))
+			Expect(string(b)).NotTo(ContainSubstring(`"division"`))
+		})
+	})
+})
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+const (
+	cactusLeagueId     = 114
+	grapefruitLeagueId = 115
+)
+
+// validateSpringLeague ensures the requested team plays spring training games
+// in either the Cactus or Grapefruit league
+func validateSpringLeague(id int) error {
+	team, ok := getTeam(id)
+	if !ok {
+		return errors.New("team not found")
+	}
+
+	switch team.SpringLeague.ID {
+	case cactusLeagueId, grapefruitLeagueId:
+		return nil
+	default:
+		return errors.New("team does not play in the Cactus or Grapefruit league")
+	}
+}
+
+// isSplitSquad reports whether the requested team is fielding split squads,
+// in which case its games are played simultaneously and are not a double header
+func isSplitSquad(id int, games []models.Game) bool {
+	for _, g := range games {
+		if g.Teams.Home.Team.ID == id && g.Teams.Home.SplitSquad {
+			return true
+		}
+		if g.Teams.Away.Team.ID == id && g.Teams.Away.SplitSquad {
+			return true
+		}
+	}
+	return false
+}
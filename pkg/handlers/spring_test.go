@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+var _ = Describe("Processing spring training schedules", Label("Spring"), func() {
+	BeforeEach(func() {
+		var teamResp models.TeamsResponse
+		setLock = new(sync.RWMutex)
+		err := json.Unmarshal([]byte(teamsAPIJSON), &teamResp)
+		if err != nil {
+			os.Exit(1)
+		}
+		createTeamsSet(teamResp)
+	})
+
+	When("We validate the requested team's spring league", func() {
+		Context("and the team plays in the Grapefruit league", func() {
+			It("should not return an error", func(ctx SpecContext) {
+				Expect(validateSpringLeague(147)).To(BeNil())
+			})
+		})
+		Context("and the team has no spring league", func() {
+			It("should return an error", func(ctx SpecContext) {
+				Expect(validateSpringLeague(159)).ToNot(BeNil())
+			})
+		})
+	})
+
+	When("We have a split squad", func() {
+		Context("and the later game is listed first", func() {
+			game1 := newTestGame(1, "2021-03-01T18:05:00Z", 147, 141, "P", "TD Ballpark")
+			game1.GameType = springTrainingGameType
+			game1.Teams.Away.SplitSquad = true
+			game2 := newTestGame(2, "2021-03-01T18:10:00Z", 111, 147, "P", "George M. Steinbrenner Field")
+			game2.GameType = springTrainingGameType
+			game2.Teams.Home.SplitSquad = true
+
+			It("the games should be chronologically ordered rather than as a double header", func(ctx SpecContext) {
+				ordered, err := orderGames(147, []models.Game{game2, game1}, nil)
+				Expect(err).To(BeNil())
+				Expect(gamePks(ordered)).To(Equal([]int{1, 2}))
+			})
+		})
+		Context("and the later game is live", func() {
+			game1 := newTestGame(1, "2021-03-01T18:05:00Z", 147, 141, "F", "TD Ballpark")
+			game1.Teams.Away.SplitSquad = true
+			game2 := newTestGame(2, "2021-03-01T18:10:00Z", 111, 147, "L", "George M. Steinbrenner Field")
+			game2.Teams.Home.SplitSquad = true
+
+			It("the live game should be listed first", func(ctx SpecContext) {
+				ordered, err := orderGames(147, []models.Game{game1, game2}, nil)
+				Expect(err).To(BeNil())
+				Expect(gamePks(ordered)).To(Equal([]int{2, 1}))
+			})
+		})
+	})
+})
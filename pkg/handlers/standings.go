@@ -0,0 +1,380 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+// structs for /standings API responses
+type StandingsResponse struct {
+	Date      string              `json:"date"`
+	Divisions []DivisionStandings `json:"divisions"`
+	WildCard  []WildCardStandings `json:"wildCard"`
+}
+
+type DivisionStandings struct {
+	League   models.League   `json:"league"`
+	Division models.Division `json:"division"`
+	Teams    []TeamStanding  `json:"teams"`
+}
+
+type WildCardStandings struct {
+	League models.League  `json:"league"`
+	Spots  int            `json:"spots"`
+	Teams  []TeamStanding `json:"teams"`
+}
+
+type TeamStanding struct {
+	Team              models.Team `json:"team"`
+	Wins              int         `json:"wins"`
+	Losses            int         `json:"losses"`
+	Pct               string      `json:"pct"`
+	GamesBack         string      `json:"gamesBack"`
+	WildCardGamesBack string      `json:"wildCardGamesBack,omitempty"`
+	Streak            string      `json:"streak"`
+	LastTen           string      `json:"lastTen"`
+	RunsScored        int         `json:"runsScored"`
+	RunsAllowed       int         `json:"runsAllowed"`
+	RunDifferential   int         `json:"runDifferential"`
+	// Verified reports whether the record matches statsapi's standings, it is
+	// omitted when statsapi's standings are unavailable
+	Verified *bool `json:"verified,omitempty"`
+
+	results []string
+}
+
+// getTeams returns the MLB clubs in the team registry ordered by ID, all-star
+// teams do not belong to a division and are excluded
+func getTeams() []models.Team {
+	setLock.RLock()
+	teams := make([]models.Team, 0, len(teamSet))
+	for _, team := range teamSet {
+		teams = append(teams, team)
+	}
+	setLock.RUnlock()
+	return divisionClubs(teams)
+}
+
+// divisionClubs returns the teams that belong to a league and division ordered by ID
+func divisionClubs(teams []models.Team) []models.Team {
+	clubs := make([]models.Team, 0, len(teams))
+	for _, team := range teams {
+		if team.League != nil && team.Division != nil {
+			clubs = append(clubs, team)
+		}
+	}
+
+	sort.Slice(clubs, func(i, j int) bool {
+		return clubs[i].ID < clubs[j].ID
+	})
+	return clubs
+}
+
+// seasonTeamsCache holds the clubs of each season fetched from statsapi, as
+// they were aligned into leagues and divisions that season
+var seasonTeamsCache = struct {
+	lock  sync.RWMutex
+	teams map[int][]models.Team
+}{teams: make(map[int][]models.Team)}
+
+// getSeasonTeamsAPIResp fetches a season's teams, tests replace it to serve
+// teams without statsapi
+var getSeasonTeamsAPIResp = fetchSeasonTeamsAPIResp
+
+func fetchSeasonTeamsAPIResp(season int) (*models.TeamsResponse, error) {
+	var teamsResp *models.TeamsResponse
+	err := getJSON(fmt.Sprintf(teamsAPIFmtStr, season), &teamsResp)
+	if err != nil {
+		return nil, err
+	}
+	if teamsResp == nil {
+		return nil, errors.New("empty teams API response")
+	}
+	return teamsResp, nil
+}
+
+// getSeasonTeams returns the MLB clubs of season ordered by ID, so that a past
+// season's standings group clubs by the divisions they played in before a
+// realignment. The team registry is used when statsapi is unavailable
+func getSeasonTeams(season int) []models.Team {
+	seasonTeamsCache.lock.RLock()
+	teams, ok := seasonTeamsCache.teams[season]
+	seasonTeamsCache.lock.RUnlock()
+	if ok {
+		return teams
+	}
+
+	teamsResp, err := getSeasonTeamsAPIResp(season)
+	if err != nil {
+		fmt.Printf("got err when hitting teams API for %d: %s\n", season, err.Error())
+		return getTeams()
+	}
+	teams = divisionClubs(teamsResp.Teams)
+	if len(teams) == 0 {
+		return getTeams()
+	}
+
+	seasonTeamsCache.lock.Lock()
+	seasonTeamsCache.teams[season] = teams
+	seasonTeamsCache.lock.Unlock()
+	return teams
+}
+
+// wildCardFormat returns the number of wild card berths per league in season,
+// and the number of teams per division that qualify for the postseason ahead
+// of the wild card. 2020's expanded postseason took the top two in each division
+func wildCardFormat(season int) (spots int, divisionBerths int) {
+	switch {
+	case season >= 2022:
+		return 3, 1
+	case season == 2020:
+		return 2, 2
+	case season >= 2012:
+		return 2, 1
+	case season >= 1994:
+		return 1, 1
+	}
+	// division winners alone qualified before the wild card was introduced
+	return 0, 1
+}
+
+// gamesBack formats the number of games a team trails another, "-" if it does not
+func gamesBack(leader TeamStanding, team TeamStanding) string {
+	diff := (leader.Wins - team.Wins) + (team.Losses - leader.Losses)
+	switch {
+	case diff == 0:
+		return "-"
+	case diff < 0:
+		return "+" + strconv.FormatFloat(float64(-diff)/2, 'f', 1, 64)
+	default:
+		return strconv.FormatFloat(float64(diff)/2, 'f', 1, 64)
+	}
+}
+
+// streak formats a team's current run of consecutive results, ie. "W3"
+func streak(results []string) string {
+	if len(results) == 0 {
+		return ""
+	}
+
+	last := results[len(results)-1]
+	n := 0
+	for i := len(results) - 1; i >= 0 && results[i] == last; i-- {
+		n++
+	}
+	return fmt.Sprintf("%s%d", last, n)
+}
+
+// lastTen formats a team's record over its last ten games, ie. "7-3"
+func lastTen(results []string) string {
+	if len(results) > 10 {
+		results = results[len(results)-10:]
+	}
+
+	var r Record
+	for _, result := range results {
+		r.add(result)
+	}
+	return fmt.Sprintf("%d-%d", r.Wins, r.Losses)
+}
+
+// sortStandings orders teams by winning percentage, then by wins
+func sortStandings(teams []TeamStanding) {
+	sort.SliceStable(teams, func(i, j int) bool {
+		pi, pj := teams[i].pct(), teams[j].pct()
+		if pi != pj {
+			return pi > pj
+		}
+		return teams[i].Wins > teams[j].Wins
+	})
+}
+
+func (s TeamStanding) pct() float64 {
+	if s.Wins+s.Losses == 0 {
+		return 0
+	}
+	return float64(s.Wins) / float64(s.Wins+s.Losses)
+}
+
+// computeStandings tallies the final games of a season's regular season into
+// division and wild card standings for teams
+func computeStandings(season int, teams []models.Team, games []models.Game) ([]DivisionStandings, []WildCardStandings) {
+	standings := make(map[int]*TeamStanding)
+	for _, team := range teams {
+		standings[team.ID] = &TeamStanding{Team: team}
+	}
+
+	sortGames(games, byFirstPitch)
+	// suspended games are listed under both the date they began and the date
+	// they were resumed, each game is counted once
+	counted := make(map[int]bool)
+	for _, g := range games {
+		if !gameCompleted(g) || counted[g.GamePk] {
+			continue
+		}
+		counted[g.GamePk] = true
+		for _, id := range []int{g.Teams.Home.Team.ID, g.Teams.Away.Team.ID} {
+			standing, ok := standings[id]
+			if !ok {
+				continue
+			}
+
+			perspective := newPerspective(id, g)
+			if perspective.Result != resultWin && perspective.Result != resultLoss {
+				// postponed and suspended games do not count towards the standings
+				continue
+			}
+			if perspective.Result == resultWin {
+				standing.Wins++
+			} else {
+				standing.Losses++
+			}
+			standing.RunsScored += int(perspective.Score)
+			standing.RunsAllowed += int(perspective.OpponentScore)
+			standing.results = append(standing.results, perspective.Result)
+		}
+	}
+
+	divisionsById := make(map[int]*DivisionStandings)
+	divisionIds := make([]int, 0)
+	for _, team := range teams {
+		standing := standings[team.ID]
+		standing.Pct = winPct(standing.Wins, standing.Losses)
+		standing.Streak = streak(standing.results)
+		standing.LastTen = lastTen(standing.results)
+		standing.RunDifferential = standing.RunsScored - standing.RunsAllowed
+
+		division, ok := divisionsById[team.Division.ID]
+		if !ok {
+			division = &DivisionStandings{League: *team.League, Division: *team.Division}
+			divisionsById[team.Division.ID] = division
+			divisionIds = append(divisionIds, team.Division.ID)
+		}
+		division.Teams = append(division.Teams, *standing)
+	}
+	sort.Ints(divisionIds)
+
+	spots, divisionBerths := wildCardFormat(season)
+	divisions := make([]DivisionStandings, 0, len(divisionIds))
+	wildCardByLeague := make(map[int]*WildCardStandings)
+	leagueIds := make([]int, 0)
+	for _, divisionId := range divisionIds {
+		division := divisionsById[divisionId]
+		sortStandings(division.Teams)
+		for i := range division.Teams {
+			division.Teams[i].GamesBack = gamesBack(division.Teams[0], division.Teams[i])
+		}
+		divisions = append(divisions, *division)
+
+		if spots == 0 {
+			continue
+		}
+		// teams qualifying through their division do not compete for a wild card berth
+		wildCard, ok := wildCardByLeague[division.League.ID]
+		if !ok {
+			wildCard = &WildCardStandings{League: division.League, Spots: spots}
+			wildCardByLeague[division.League.ID] = wildCard
+			leagueIds = append(leagueIds, division.League.ID)
+		}
+		if len(division.Teams) > divisionBerths {
+			wildCard.Teams = append(wildCard.Teams, division.Teams[divisionBerths:]...)
+		}
+	}
+	sort.Ints(leagueIds)
+
+	wildCards := make([]WildCardStandings, 0, len(leagueIds))
+	for _, leagueId := range leagueIds {
+		wildCard := wildCardByLeague[leagueId]
+		sortStandings(wildCard.Teams)
+		if wildCard.Spots > 0 && len(wildCard.Teams) >= wildCard.Spots {
+			lastSpot := wildCard.Teams[wildCard.Spots-1]
+			for i := range wildCard.Teams {
+				wildCard.Teams[i].WildCardGamesBack = gamesBack(lastSpot, wildCard.Teams[i])
+			}
+		}
+		wildCards = append(wildCards, *wildCard)
+	}
+	return divisions, wildCards
+}
+
+// verifyStandings flags whether each team's computed record matches statsapi's standings
+func verifyStandings(divisions []DivisionStandings, wildCards []WildCardStandings, standingsResp *models.StandingsResponse) {
+	upstream := make(map[int]models.StandingsTeamRecord)
+	for _, record := range standingsResp.Records {
+		for _, teamRecord := range record.TeamRecords {
+			upstream[teamRecord.Team.ID] = teamRecord
+		}
+	}
+
+	verify := func(teams []TeamStanding) {
+		for i := range teams {
+			teamRecord, ok := upstream[teams[i].Team.ID]
+			if !ok {
+				continue
+			}
+			verified := teamRecord.Wins == teams[i].Wins && teamRecord.Losses == teams[i].Losses
+			teams[i].Verified = &verified
+		}
+	}
+	for _, division := range divisions {
+		verify(division.Teams)
+	}
+	for _, wildCard := range wildCards {
+		verify(wildCard.Teams)
+	}
+}
+
+// GetStandings serves the /standings?date=<YYYY-MM-DD> API which allows a
+// client to receive division and wild card standings as of a date, computed
+// from the season's final regular season games
+func GetStandings(c *gin.Context) {
+	date := c.Query("date")
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: "invalid date string", Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	seasonStart := fmt.Sprintf("%d-01-01", day.Year())
+	if scheduleStore != nil {
+		// the dates before opening day are off days or spring training, which
+		// need not be stored
+		if season, err := getSeason(day.Year()); err == nil && season.RegularSeasonStartDate != "" {
+			seasonStart = season.RegularSeasonStartDate
+		}
+	}
+	regularSeason := func(g models.Game) bool {
+		return g.GameType == regularSeasonGameType
+	}
+	schedResp, err := getDateRangeSchedule(seasonStart, date, regularSeason, func(startDate string, endDate string) (*models.ScheduleResponse, error) {
+		return getScheduleAPIResp(fmt.Sprintf(regularSeasonAPIFmtStr, startDate, endDate))
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	games := make([]models.Game, 0)
+	for _, d := range schedResp.Dates {
+		games = append(games, filterGameTypes(map[string]bool{regularSeasonGameType: true}, d.Games)...)
+	}
+	divisions, wildCards := computeStandings(day.Year(), getSeasonTeams(day.Year()), games)
+
+	// cross check against statsapi's standings when they are available
+	standingsResp, err := getStandingsAPIResp(fmt.Sprintf(standingsAPIFmtStr, day.Year(), date))
+	if err != nil {
+		fmt.Printf("got err when hitting standings API: %s\n", err.Error())
+	} else {
+		verifyStandings(divisions, wildCards, standingsResp)
+	}
+
+	c.JSON(http.StatusOK, StandingsResponse{Date: date, Divisions: divisions, WildCard: wildCards})
+}
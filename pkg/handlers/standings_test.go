@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+func newTestFinal(gamePk int, gameDate string, awayId int, awayScore uint8, homeId int, homeScore uint8) models.Game {
+	g := newTestGame(gamePk, gameDate, awayId, homeId, "F", "")
+	g.Status.DetailedState = "Final"
+	g.Teams.Away.Score = awayScore
+	g.Teams.Home.Score = homeScore
+	g.Teams.Away.IsWinner = awayScore > homeScore
+	g.Teams.Home.IsWinner = homeScore > awayScore
+	return g
+}
+
+func findStanding(teams []TeamStanding, id int) TeamStanding {
+	for _, standing := range teams {
+		if standing.Team.ID == id {
+			return standing
+		}
+	}
+	return TeamStanding{}
+}
+
+var _ = Describe("Processing Standings requests", Label("Standings"), func() {
+	var games []models.Game
+
+	BeforeEach(func() {
+		var teamResp models.TeamsResponse
+		setLock = new(sync.RWMutex)
+		err := json.Unmarshal([]byte(teamsAPIJSON), &teamResp)
+		if err != nil {
+			os.Exit(1)
+		}
+		createTeamsSet(teamResp)
+
+		postponed := newTestGame(6, "2021-04-04T17:05:00Z", 141, 147, "F", "")
+		postponed.Status.DetailedState = "Postponed"
+		games = []models.Game{
+			newTestFinal(1, "2021-04-01T17:05:00Z", 141, 2, 147, 5),
+			newTestFinal(2, "2021-04-02T17:05:00Z", 141, 1, 147, 3),
+			newTestFinal(3, "2021-04-03T17:05:00Z", 141, 7, 147, 6),
+			newTestFinal(4, "2021-04-01T17:05:00Z", 110, 4, 111, 2),
+			newTestFinal(5, "2021-04-02T17:05:00Z", 110, 4, 111, 3),
+			postponed,
+			newTestGame(7, "2021-04-05T17:05:00Z", 141, 147, "P", ""),
+		}
+	})
+
+	When("We format streaks and recent records", func() {
+		It("should describe the current run of results", func(ctx SpecContext) {
+			Expect(streak([]string{"W", "L", "L"})).To(Equal("L2"))
+			Expect(streak(nil)).To(Equal(""))
+		})
+		It("should only consider the last ten games", func(ctx SpecContext) {
+			results := []string{"L", "L", "W", "W", "W", "W", "W", "W", "W", "L", "L", "W"}
+			Expect(lastTen(results)).To(Equal("8-2"))
+		})
+	})
+
+	When("We compute standings from final games", func() {
+		It("should tally records, run differential and streaks per team", func(ctx SpecContext) {
+			divisions, _ := computeStandings(2021, getTeams(), games)
+			Expect(divisions).To(HaveLen(6))
+
+			alEast := divisions[1]
+			Expect(alEast.Division.ID).To(Equal(201))
+			nyy := findStanding(alEast.Teams, 147)
+			Expect(nyy.Wins).To(Equal(2))
+			Expect(nyy.Losses).To(Equal(1))
+			Expect(nyy.Pct).To(Equal(".667"))
+			Expect(nyy.RunDifferential).To(Equal(4))
+			Expect(nyy.Streak).To(Equal("L1"))
+			Expect(nyy.LastTen).To(Equal("2-1"))
+		})
+
+		It("should count a suspended game once, when it is completed", func(ctx SpecContext) {
+			// NYY lead when the game was suspended, and lost once it was resumed
+			suspended := newTestFinal(8, "2021-04-06T17:05:00Z", 141, 1, 147, 2)
+			suspended.Status.DetailedState = "Suspended: Rain"
+			resumed := newTestFinal(8, "2021-04-07T17:05:00Z", 141, 4, 147, 2)
+			resumed.OfficialDate = "2021-04-07"
+			games = append(games, suspended, resumed, resumed)
+
+			divisions, _ := computeStandings(2021, getTeams(), games)
+			nyy := findStanding(divisions[1].Teams, 147)
+			Expect(nyy.Wins).To(Equal(2))
+			Expect(nyy.Losses).To(Equal(2))
+			Expect(nyy.RunDifferential).To(Equal(2))
+		})
+
+		It("should order divisions by winning percentage with games back", func(ctx SpecContext) {
+			divisions, _ := computeStandings(2021, getTeams(), games)
+
+			alEast := divisions[1]
+			Expect(alEast.Teams[0].Team.ID).To(Equal(110))
+			Expect(alEast.Teams[0].GamesBack).To(Equal("-"))
+			Expect(findStanding(alEast.Teams, 147).GamesBack).To(Equal("0.5"))
+			Expect(findStanding(alEast.Teams, 111).GamesBack).To(Equal("2.0"))
+		})
+
+		It("should exclude division leaders from the wild card", func(ctx SpecContext) {
+			_, wildCards := computeStandings(2021, getTeams(), games)
+			Expect(wildCards).To(HaveLen(2))
+
+			al := wildCards[0]
+			Expect(al.League.ID).To(Equal(103))
+			Expect(al.Spots).To(Equal(2))
+			Expect(al.Teams).To(HaveLen(12))
+			Expect(al.Teams[0].Team.ID).To(Equal(147))
+			Expect(al.Teams[0].WildCardGamesBack).To(Equal("+1.0"))
+		})
+
+		It("should follow the season's postseason format", func(ctx SpecContext) {
+			_, wildCards := computeStandings(2011, getTeams(), games)
+			Expect(wildCards[0].Spots).To(Equal(1))
+			Expect(wildCards[0].Teams).To(HaveLen(12))
+
+			// the top two in each division qualified in 2020
+			_, wildCards = computeStandings(2020, getTeams(), games)
+			Expect(wildCards[0].Spots).To(Equal(2))
+			Expect(wildCards[0].Teams).To(HaveLen(9))
+			Expect(wildCards[0].Teams).NotTo(ContainElement(HaveField("Team.ID", 147)))
+
+			_, wildCards = computeStandings(2022, getTeams(), games)
+			Expect(wildCards[0].Spots).To(Equal(3))
+
+			_, wildCards = computeStandings(1993, getTeams(), games)
+			Expect(wildCards).To(BeEmpty())
+		})
+	})
+
+	When("We compute a season's standings from before a realignment", func() {
+		AfterEach(func() {
+			getSeasonTeamsAPIResp = fetchSeasonTeamsAPIResp
+			seasonTeamsCache.lock.Lock()
+			seasonTeamsCache.teams = make(map[int][]models.Team)
+			seasonTeamsCache.lock.Unlock()
+		})
+
+		It("should group clubs by the divisions they played in that season", func(ctx SpecContext) {
+			var seasons []int
+			getSeasonTeamsAPIResp = func(season int) (*models.TeamsResponse, error) {
+				seasons = append(seasons, season)
+				var teamResp models.TeamsResponse
+				Expect(json.Unmarshal([]byte(teamsAPIJSON), &teamResp)).To(Succeed())
+				for i, team := range teamResp.Teams {
+					if team.ID == 117 {
+						// the Astros played in the NL Central until 2013
+						teamResp.Teams[i].League = &models.League{ID: 104, Name: "National League"}
+						teamResp.Teams[i].Division = &models.Division{ID: 205, Name: "National League Central"}
+					}
+				}
+				return &teamResp, nil
+			}
+
+			games = append(games, newTestFinal(9, "2012-04-06T18:05:00Z", 117, 5, 138, 3))
+			divisions, _ := computeStandings(2012, getSeasonTeams(2012), games)
+			standings := make(map[int][]TeamStanding)
+			for _, division := range divisions {
+				standings[division.Division.ID] = division.Teams
+			}
+			Expect(standings[205]).To(ContainElement(HaveField("Team.ID", 117)))
+			Expect(standings[200]).NotTo(ContainElement(HaveField("Team.ID", 117)))
+
+			// each season's teams are fetched once
+			getSeasonTeams(2012)
+			Expect(seasons).To(Equal([]int{2012}))
+		})
+
+		It("should fall back to the team registry when statsapi is unavailable", func(ctx SpecContext) {
+			getSeasonTeamsAPIResp = func(season int) (*models.TeamsResponse, error) {
+				return nil, errors.New("statsapi unreachable")
+			}
+			Expect(getSeasonTeams(2012)).To(Equal(getTeams()))
+		})
+	})
+
+	When("We cross check against statsapi's standings", func() {
+		It("should flag records that do not match", func(ctx SpecContext) {
+			divisions, wildCards := computeStandings(2021, getTeams(), games)
+			verifyStandings(divisions, wildCards, &models.StandingsResponse{
+				Records: []models.StandingsRecord{{
+					TeamRecords: []models.StandingsTeamRecord{
+						{Team: models.Team{ID: 147}, Wins: 2, Losses: 1},
+						{Team: models.Team{ID: 141}, Wins: 2, Losses: 1},
+					},
+				}},
+			})
+
+			alEast := divisions[1]
+			Expect(*findStanding(alEast.Teams, 147).Verified).To(BeTrue())
+			Expect(*findStanding(alEast.Teams, 141).Verified).To(BeFalse())
+			Expect(findStanding(alEast.Teams, 111).Verified).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	eventGameLive         = "gameLive"
+	eventGameFinal        = "gameFinal"
+	eventGamePostponed    = "gamePostponed"
+	eventStartTimeChanged = "startTimeChanged"
+)
+
+var validEvents = map[string]bool{
+	eventGameLive:         true,
+	eventGameFinal:        true,
+	eventGamePostponed:    true,
+	eventStartTimeChanged: true,
+}
+
+// structs for /subscriptions API requests and responses
+type SubscriptionRequest struct {
+	TeamID      int      `json:"teamId"`
+	Events      []string `json:"events"`
+	CallbackURL string   `json:"callbackUrl"`
+	// Secret signs delivered payloads, one is generated when it is omitted
+	Secret string `json:"secret"`
+}
+
+type Subscription struct {
+	ID          string   `json:"id"`
+	TeamID      int      `json:"teamId"`
+	Events      []string `json:"events"`
+	CallbackURL string   `json:"callbackUrl"`
+	// Secret is only returned when the subscription is created
+	Secret    string `json:"secret,omitempty"`
+	CreatedAt string `json:"createdAt"`
+}
+
+var (
+	errSubscriptionNotFound  = errors.New("subscription not found")
+	errSubscriptionForbidden = errors.New("secret does not match the subscription")
+)
+
+type SubscriptionsResponse struct {
+	Subscriptions []Subscription `json:"subscriptions"`
+}
+
+// subscriptionRegistry holds the registered webhook subscriptions by ID
+type subscriptionRegistry struct {
+	lock          sync.RWMutex
+	subscriptions map[string]Subscription
+}
+
+var subscriptions = newSubscriptionRegistry()
+
+func newSubscriptionRegistry() *subscriptionRegistry {
+	return &subscriptionRegistry{subscriptions: make(map[string]Subscription)}
+}
+
+func (r *subscriptionRegistry) add(sub Subscription) {
+	r.lock.Lock()
+	r.subscriptions[sub.ID] = sub
+	r.lock.Unlock()
+}
+
+// remove deletes the subscription with id, provided secret is its secret
+func (r *subscriptionRegistry) remove(id string, secret string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	sub, ok := r.subscriptions[id]
+	if !ok {
+		return errSubscriptionNotFound
+	}
+	if !sub.hasSecret(secret) {
+		return errSubscriptionForbidden
+	}
+	delete(r.subscriptions, id)
+	return nil
+}
+
+// list returns every subscription ordered by creation
+func (r *subscriptionRegistry) list() []Subscription {
+	r.lock.RLock()
+	subs := make([]Subscription, 0, len(r.subscriptions))
+	for _, sub := range r.subscriptions {
+		subs = append(subs, sub)
+	}
+	r.lock.RUnlock()
+
+	sort.Slice(subs, func(i, j int) bool {
+		if subs[i].CreatedAt != subs[j].CreatedAt {
+			return subs[i].CreatedAt < subs[j].CreatedAt
+		}
+		return subs[i].ID < subs[j].ID
+	})
+	return subs
+}
+
+// owned returns the subscriptions created with secret ordered by creation,
+// with their secrets redacted
+func (r *subscriptionRegistry) owned(secret string) []Subscription {
+	owned := make([]Subscription, 0)
+	for _, sub := range r.list() {
+		if sub.hasSecret(secret) {
+			sub.Secret = ""
+			owned = append(owned, sub)
+		}
+	}
+	return owned
+}
+
+func (s Subscription) hasSecret(secret string) bool {
+	return hmac.Equal([]byte(s.Secret), []byte(secret))
+}
+
+// matching returns the subscriptions to event for any of the teams
+func (r *subscriptionRegistry) matching(event string, teamIds ...int) []Subscription {
+	matches := make([]Subscription, 0)
+	for _, sub := range r.list() {
+		for _, teamId := range teamIds {
+			if sub.TeamID == teamId && containsString(sub.Events, event) {
+				matches = append(matches, sub)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func validateSubscriptionRequest(req SubscriptionRequest) error {
+	err := validateTeam(req.TeamID)
+	if err != nil {
+		return err
+	}
+
+	if len(req.Events) == 0 {
+		return errors.New("at least one event is required")
+	}
+	for _, event := range req.Events {
+		if !validEvents[event] {
+			return fmt.Errorf("invalid event: %s", event)
+		}
+	}
+
+	callback, err := url.Parse(req.CallbackURL)
+	if err != nil || (callback.Scheme != "http" && callback.Scheme != "https") || callback.Host == "" {
+		return errors.New("invalid callbackUrl")
+	}
+	// names are checked again once resolved, when payloads are delivered
+	host := callback.Hostname()
+	if ip := net.ParseIP(host); strings.EqualFold(host, "localhost") || (ip != nil && !publicIP(ip)) {
+		return errors.New("callbackUrl must not be a loopback, private or link-local address")
+	}
+	return nil
+}
+
+// subscriptionSecret reads the secret a client identifies its subscriptions
+// by from an "Authorization: Bearer <secret>" header, responding with a 401
+// when there is none
+func subscriptionSecret(c *gin.Context) (string, bool) {
+	secret, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || secret == "" {
+		c.Header("WWW-Authenticate", "Bearer")
+		c.JSON(http.StatusUnauthorized, ScheduleErrorResponse{Message: "the subscription secret is required as a bearer token", Timestamp: time.Now().UTC().String()})
+		return "", false
+	}
+	return secret, true
+}
+
+// CreateSubscription serves POST /subscriptions which registers a webhook
+// receiving signed payloads when a team's games change state
+func CreateSubscription(c *gin.Context) {
+	var req SubscriptionRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	err = validateSubscriptionRequest(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	id, err := randomHex(16)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+	if req.Secret == "" {
+		req.Secret, err = randomHex(32)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+			return
+		}
+	}
+
+	sub := Subscription{
+		ID:          id,
+		TeamID:      req.TeamID,
+		Events:      req.Events,
+		CallbackURL: req.CallbackURL,
+		Secret:      req.Secret,
+		CreatedAt:   time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	subscriptions.add(sub)
+	c.JSON(http.StatusCreated, sub)
+}
+
+// GetSubscriptions serves GET /subscriptions which lists the webhooks
+// registered with the secret given as a bearer token
+func GetSubscriptions(c *gin.Context) {
+	secret, ok := subscriptionSecret(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, SubscriptionsResponse{Subscriptions: subscriptions.owned(secret)})
+}
+
+// DeleteSubscription serves DELETE /subscriptions/{id} which stops deliveries
+// to a webhook, the webhook's secret must be given as a bearer token
+func DeleteSubscription(c *gin.Context) {
+	secret, ok := subscriptionSecret(c)
+	if !ok {
+		return
+	}
+
+	err := subscriptions.remove(c.Param("id"), secret)
+	switch {
+	case errors.Is(err, errSubscriptionNotFound):
+		c.JSON(http.StatusNotFound, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	case errors.Is(err, errSubscriptionForbidden):
+		c.JSON(http.StatusForbidden, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
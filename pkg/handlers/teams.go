@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+// teamIndex maps every normalized name a team is known by to the IDs of the
+// teams known by it, it is rebuilt alongside teamSet and guarded by setLock
+var teamIndex map[string][]int
+
+// ErrTeamNotFound is returned when a team query matches no team in the registry
+var ErrTeamNotFound = errors.New("team not found")
+
+// minPrefixLength is the shortest query matched against the start of a team's names
+const minPrefixLength = 3
+
+// TeamCandidate is one of several teams an ambiguous team query could refer to
+type TeamCandidate struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Abbreviation string `json:"abbreviation,omitempty"`
+}
+
+// ambiguousTeamError is returned when a team query matches more than one team
+type ambiguousTeamError struct {
+	query      string
+	candidates []TeamCandidate
+}
+
+func (e *ambiguousTeamError) Error() string {
+	names := make([]string, 0, len(e.candidates))
+	for _, c := range e.candidates {
+		names = append(names, c.Name)
+	}
+	return fmt.Sprintf("ambiguous team %q, could be: %s", e.query, strings.Join(names, ", "))
+}
+
+func normalizeTeamName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// teamNames lists every name a team is known by in the teams API
+func teamNames(team models.Team) []string {
+	return []string{
+		team.Name,
+		team.Abbreviation,
+		team.TeamName,
+		team.ShortName,
+		team.TeamCode,
+		team.FileCode,
+		team.ClubName,
+		team.FranchiseName,
+		team.LocationName,
+	}
+}
+
+// buildTeamIndex indexes the names of every team in teamSet, callers must hold setLock
+func buildTeamIndex() {
+	teamIndex = make(map[string][]int)
+	for id, team := range teamSet {
+		seen := make(map[string]bool)
+		for _, name := range teamNames(team) {
+			key := normalizeTeamName(name)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			teamIndex[key] = append(teamIndex[key], id)
+		}
+	}
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j] + 1
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+			if prev[j-1]+cost < curr[j] {
+				curr[j] = prev[j-1] + cost
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// maxTypos is the edit distance tolerated for a query, short queries such as
+// abbreviations must match exactly
+func maxTypos(query string) int {
+	switch {
+	case len(query) < 5:
+		return 0
+	case len(query) < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// matchTeams finds the IDs of the teams best matching a normalized query,
+// trying exact names, then names with a word the query begins, then names
+// within a few typos.
+// Callers must hold setLock
+func matchTeams(query string) []int {
+	matches := make(map[int]bool)
+	for _, id := range teamIndex[query] {
+		matches[id] = true
+	}
+
+	if len(matches) == 0 && len(query) >= minPrefixLength {
+		for key, ids := range teamIndex {
+			if strings.HasPrefix(key, query) || strings.Contains(key, " "+query) {
+				for _, id := range ids {
+					matches[id] = true
+				}
+			}
+		}
+	}
+
+	if len(matches) == 0 && maxTypos(query) > 0 {
+		best := maxTypos(query) + 1
+		for key, ids := range teamIndex {
+			d := editDistance(query, key)
+			if d > best {
+				continue
+			}
+			if d < best {
+				best = d
+				matches = make(map[int]bool)
+			}
+			for _, id := range ids {
+				matches[id] = true
+			}
+		}
+		if best > maxTypos(query) {
+			matches = make(map[int]bool)
+		}
+	}
+
+	ids := make([]int, 0, len(matches))
+	for id := range matches {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// LookupTeam resolves a team ID, abbreviation (ie. NYY), team or club name
+// (ie. Yankees), location (ie. Bronx), franchise (ie. New York) or a close
+// misspelling of any of them to a team in the registry. A query matching
+// several teams returns an error listing the candidates
+func LookupTeam(query string) (models.Team, error) {
+	query = strings.TrimSpace(query)
+	if id, err := strconv.Atoi(query); err == nil {
+		team, ok := getTeam(id)
+		if !ok {
+			return models.Team{}, fmt.Errorf("%w: %s", ErrTeamNotFound, query)
+		}
+		return team, nil
+	}
+
+	setLock.RLock()
+	defer setLock.RUnlock()
+
+	ids := matchTeams(normalizeTeamName(query))
+	switch len(ids) {
+	case 0:
+		return models.Team{}, fmt.Errorf("%w: %s", ErrTeamNotFound, query)
+	case 1:
+		return teamSet[ids[0]], nil
+	}
+
+	candidates := make([]TeamCandidate, 0, len(ids))
+	for _, id := range ids {
+		team := teamSet[id]
+		candidates = append(candidates, TeamCandidate{ID: team.ID, Name: team.Name, Abbreviation: team.Abbreviation})
+	}
+	return models.Team{}, &ambiguousTeamError{query: query, candidates: candidates}
+}
+
+// Teams returns the clubs in the team registry ordered by ID
+func Teams() []models.Team {
+	return getTeams()
+}
+
+// parseTeamParameter resolves the team=<id, abbreviation or name> query
+// parameter, falling back to the numeric teamId=<id> parameter
+func parseTeamParameter(c *gin.Context) (int, error) {
+	query, ok := c.GetQuery("team")
+	if !ok {
+		return strconv.Atoi(c.Query("teamId"))
+	}
+
+	team, err := LookupTeam(query)
+	if err != nil {
+		return 0, err
+	}
+	return team.ID, nil
+}
+
+// teamErrorResponse lists the candidates of an ambiguous team query alongside the error
+func teamErrorResponse(err error) ScheduleErrorResponse {
+	resp := ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()}
+	var ambiguous *ambiguousTeamError
+	if errors.As(err, &ambiguous) {
+		resp.Candidates = ambiguous.candidates
+	}
+	return resp
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+var _ = Describe("Looking up teams", Label("Teams"), func() {
+	BeforeEach(func() {
+		var teamResp models.TeamsResponse
+		setLock = new(sync.RWMutex)
+		err := json.Unmarshal([]byte(teamsAPIJSON), &teamResp)
+		if err != nil {
+			os.Exit(1)
+		}
+		createTeamsSet(teamResp)
+	})
+
+	When("We look up a team", func() {
+		It("should accept its ID, abbreviation or names regardless of case", func(ctx SpecContext) {
+			for _, query := range []string{"147", "NYY", "nyy", "nya", "Yankees", "NY Yankees", "new york yankees", "Bronx"} {
+				team, err := LookupTeam(query)
+				Expect(err).To(BeNil())
+				Expect(team.ID).To(Equal(147))
+			}
+		})
+
+		It("should not find teams that do not exist", func(ctx SpecContext) {
+			_, err := LookupTeam("1")
+			Expect(err).NotTo(BeNil())
+			_, err = LookupTeam("Expos")
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	When("We look up a team by part of its name", func() {
+		It("should match the start of any word", func(ctx SpecContext) {
+			team, err := LookupTeam("yank")
+			Expect(err).To(BeNil())
+			Expect(team.ID).To(Equal(147))
+		})
+	})
+
+	When("We misspell a team", func() {
+		It("should tolerate a typo", func(ctx SpecContext) {
+			team, err := LookupTeam("Yankes")
+			Expect(err).To(BeNil())
+			Expect(team.ID).To(Equal(147))
+		})
+
+		It("should not tolerate a typo in an abbreviation", func(ctx SpecContext) {
+			_, err := LookupTeam("NYX")
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	When("A query matches several teams", func() {
+		It("should list the candidates", func(ctx SpecContext) {
+			_, err := LookupTeam("New York")
+			var ambiguous *ambiguousTeamError
+			Expect(err).To(BeAssignableToTypeOf(ambiguous))
+			Expect(err.(*ambiguousTeamError).candidates).To(Equal([]TeamCandidate{
+				{ID: 121, Name: "New York Mets", Abbreviation: "NYM"},
+				{ID: 147, Name: "New York Yankees", Abbreviation: "NYY"},
+			}))
+
+			_, err = LookupTeam("sox")
+			Expect(err).To(BeAssignableToTypeOf(ambiguous))
+			Expect(err.(*ambiguousTeamError).candidates).To(HaveLen(2))
+		})
+
+		It("should respond with a 400 listing the candidates", func(ctx SpecContext) {
+			router := gin.New()
+			router.GET("/schedule", GetSchedule)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/schedule?team=New+York&date=2021-09-11", nil))
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+
+			var resp ScheduleErrorResponse
+			Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+			Expect(resp.Candidates).To(HaveLen(2))
+		})
+	})
+
+	When("We request a schedule with invalid parameters", func() {
+		It("should fail before fetching the schedule", func(ctx SpecContext) {
+			_, err := Schedule(ScheduleQuery{TeamID: 147, Date: "09-11-2021"})
+			Expect(err).To(MatchError("invalid date string"))
+			_, err = Schedule(ScheduleQuery{TeamID: 147, Date: "2021-09-11", Sort: "alphabetical"})
+			Expect(err).NotTo(BeNil())
+		})
+	})
+})
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+// structs for /teams/{id}/schedule API responses
+type TeamScheduleResponse struct {
+	Team   models.Team  `json:"team"`
+	Season string       `json:"season"`
+	Record Record       `json:"record"`
+	Home   Record       `json:"home"`
+	Away   Record       `json:"away"`
+	Series []TeamSeries `json:"series"`
+	Next   string       `json:"next,omitempty"`
+}
+
+type TeamSeries struct {
+	SeriesNumber  uint8              `json:"seriesNumber"`
+	GamesInSeries uint8              `json:"gamesInSeries"`
+	Opponent      models.Team        `json:"opponent"`
+	HomeAway      string             `json:"homeAway"`
+	Record        Record             `json:"record"`
+	Games         []TeamScheduleGame `json:"games"`
+}
+
+type TeamScheduleGame struct {
+	models.Game
+	RunningRecord Record `json:"runningRecord"`
+}
+
+// Record tallies the results of completed games
+type Record struct {
+	Wins   int    `json:"wins"`
+	Losses int    `json:"losses"`
+	Ties   int    `json:"ties,omitempty"`
+	Pct    string `json:"pct"`
+}
+
+func (r *Record) add(result string) {
+	switch result {
+	case resultWin:
+		r.Wins++
+	case resultLoss:
+		r.Losses++
+	case resultTie:
+		r.Ties++
+	default:
+		return
+	}
+	r.Pct = winPct(r.Wins, r.Losses)
+}
+
+// winPct formats a winning percentage the way statsapi does, ie. ".563"
+func winPct(wins int, losses int) string {
+	if wins+losses == 0 {
+		return ".000"
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%.3f", float64(wins)/float64(wins+losses)), "0")
+}
+
+// buildTeamSchedule orders a team's season schedule by date, with double
+// headers ordered like /schedule, and groups the games into series while
+// tallying the team's running, home and away records. Records count completed
+// games once, as /standings does, a suspended game is listed on both the date
+// it began and the date it resumed
+func buildTeamSchedule(id int, dates []models.Date) (*TeamScheduleResponse, error) {
+	resp := &TeamScheduleResponse{
+		Record: Record{Pct: winPct(0, 0)},
+		Home:   Record{Pct: winPct(0, 0)},
+		Away:   Record{Pct: winPct(0, 0)},
+		Series: make([]TeamSeries, 0),
+	}
+
+	var series *TeamSeries
+	counted := make(map[int]bool)
+	for _, d := range dates {
+		games, err := orderGames(id, d.Games, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, g := range games {
+			perspective := newPerspective(id, g)
+			if perspective == nil {
+				continue
+			}
+			g.Perspective = perspective
+
+			seriesNumber := g.Teams.Home.SeriesNumber
+			if perspective.HomeAway == awayTeam {
+				seriesNumber = g.Teams.Away.SeriesNumber
+			}
+			if series == nil || series.SeriesNumber != seriesNumber || series.Opponent.ID != perspective.Opponent.ID {
+				resp.Series = append(resp.Series, TeamSeries{
+					SeriesNumber:  seriesNumber,
+					GamesInSeries: g.GamesInSeries,
+					Opponent:      perspective.Opponent,
+					HomeAway:      perspective.HomeAway,
+					Record:        Record{Pct: winPct(0, 0)},
+					Games:         make([]TeamScheduleGame, 0),
+				})
+				series = &resp.Series[len(resp.Series)-1]
+			}
+
+			if gameCompleted(g) && !counted[g.GamePk] {
+				counted[g.GamePk] = true
+				resp.Record.add(perspective.Result)
+				series.Record.add(perspective.Result)
+				if perspective.HomeAway == homeTeam {
+					resp.Home.add(perspective.Result)
+				} else {
+					resp.Away.add(perspective.Result)
+				}
+			}
+			series.Games = append(series.Games, TeamScheduleGame{Game: g, RunningRecord: resp.Record})
+		}
+	}
+	return resp, nil
+}
+
+// pageSeries keeps the games of each series listed on a page, leaving out the
+// series without any
+func pageSeries(series []TeamSeries, listed func(g models.Game) bool) []TeamSeries {
+	paged := make([]TeamSeries, 0)
+	for _, s := range series {
+		games := make([]TeamScheduleGame, 0)
+		for _, g := range s.Games {
+			if listed(g.Game) {
+				games = append(games, g)
+			}
+		}
+		if len(games) == 0 {
+			continue
+		}
+		s.Games = games
+		paged = append(paged, s)
+	}
+	return paged
+}
+
+// getTeamSeasonSchedule returns a team's games across a season, read from the
+// store for the dates stored once final when the season's dates are known
+func getTeamSeasonSchedule(id int, season string) (*models.ScheduleResponse, error) {
+	year, err := strconv.Atoi(season)
+	if err != nil || scheduleStore == nil {
+		return getScheduleAPIResp(fmt.Sprintf(teamScheduleAPIFmtStr, id, season))
+	}
+	s, err := getSeason(year)
+	if err != nil || s.SeasonStartDate == "" || s.SeasonEndDate == "" {
+		return getScheduleAPIResp(fmt.Sprintf(teamScheduleAPIFmtStr, id, season))
+	}
+
+	plays := func(g models.Game) bool {
+		return g.Teams.Home.Team.ID == id || g.Teams.Away.Team.ID == id
+	}
+	return getDateRangeSchedule(s.SeasonStartDate, s.SeasonEndDate, plays, func(startDate string, endDate string) (*models.ScheduleResponse, error) {
+		return getScheduleAPIResp(fmt.Sprintf(teamRangeAPIFmtStr, id, startDate, endDate))
+	})
+}
+
+// GetTeamSchedule serves the /teams/{id}/schedule?season=<YYYY> API which
+// allows a client to receive a team's games across a season grouped by series,
+// gameType=<type,...> defaults to the regular season. An optional limit=<n>
+// pages the games across dates
+func GetTeamSchedule(c *gin.Context) {
+	format, err := negotiateFormat(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	err = validateTeam(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	season := c.Query("season")
+	err = validateSeason(season)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	gameTypes, err := parseGameTypes(c.DefaultQuery("gameType", regularSeasonGameType))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	limit, cursor, err := parsePageParameters(c.Query("limit"), c.Query("cursor"), "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	schedResp, err := getTeamSeasonSchedule(id, season)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	for i := range schedResp.Dates {
+		games := filterGameTypes(gameTypes, schedResp.Dates[i].Games)
+		// pages follow the order games are listed in
+		schedResp.Dates[i].Games, err = orderGames(id, games, nil)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+			return
+		}
+	}
+	page, next := paginateDates(schedResp.Dates, limit, cursor)
+	if format != formatJSON {
+		// exports carry the next page's link in a header, as they have no envelope
+		if next != nil {
+			c.Header("Link", fmt.Sprintf("<%s>; rel=\"next\"", nextLink(c, *next)))
+		}
+		writeGames(c, format, page)
+		return
+	}
+
+	// records are tallied over the whole season, then trimmed to the page
+	resp, err := buildTeamSchedule(id, schedResp.Dates)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+	resp.Series = pageSeries(resp.Series, onPage(page))
+	resp.Team, _ = getTeam(id)
+	resp.Season = season
+	if next != nil {
+		resp.Next = nextLink(c, *next)
+	}
+	c.JSON(http.StatusOK, resp)
+}
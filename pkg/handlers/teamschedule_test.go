@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+func newTestSeriesGame(gamePk int, gameDate string, awayId int, homeId int, seriesNumber uint8, homeWins bool) models.Game {
+	g := newTestGame(gamePk, gameDate, awayId, homeId, "F", "")
+	g.Status.DetailedState = "Final"
+	g.OfficialDate = gameDate[:10]
+	g.GamesInSeries = 3
+	g.Teams.Home.SeriesNumber = seriesNumber
+	g.Teams.Away.SeriesNumber = seriesNumber
+	g.Teams.Home.IsWinner = homeWins
+	g.Teams.Away.IsWinner = !homeWins
+	return g
+}
+
+var _ = Describe("Processing Team Schedule requests", Label("TeamSchedule"), func() {
+	When("We format a winning percentage", func() {
+		It("should match statsapi's format", func(ctx SpecContext) {
+			Expect(winPct(0, 0)).To(Equal(".000"))
+			Expect(winPct(80, 62)).To(Equal(".563"))
+			Expect(winPct(3, 0)).To(Equal("1.000"))
+		})
+	})
+
+	When("We build a team's season schedule", func() {
+		// second game of the double header is listed first by statsapi
+		dh2 := newTestSeriesGame(4, "2021-04-05T20:00:00Z", 111, 147, 2, false)
+		dh2.DoubleHeader = "Y"
+		dh2.Status.StartTimeTBD = true
+		dh1 := newTestSeriesGame(3, "2021-04-05T17:00:00Z", 111, 147, 2, true)
+		dh1.DoubleHeader = "Y"
+		upcoming := newTestSeriesGame(5, "2021-04-06T23:05:00Z", 111, 147, 2, false)
+		upcoming.Status.AbstractGameCode = "P"
+		upcoming.Teams.Away.IsWinner = false
+
+		dates := []models.Date{
+			{Date: "2021-04-01", Games: []models.Game{newTestSeriesGame(1, "2021-04-01T17:05:00Z", 147, 141, 1, true)}},
+			{Date: "2021-04-02", Games: []models.Game{newTestSeriesGame(2, "2021-04-02T17:05:00Z", 147, 141, 1, false)}},
+			{Date: "2021-04-05", Games: []models.Game{dh2, dh1}},
+			{Date: "2021-04-06", Games: []models.Game{upcoming}},
+		}
+
+		It("should group games into series with double headers in order", func(ctx SpecContext) {
+			resp, err := buildTeamSchedule(147, dates)
+			Expect(err).To(BeNil())
+			Expect(resp.Series).To(HaveLen(2))
+
+			Expect(resp.Series[0].Opponent.ID).To(Equal(141))
+			Expect(resp.Series[0].HomeAway).To(Equal(awayTeam))
+			Expect(resp.Series[0].Games).To(HaveLen(2))
+
+			Expect(resp.Series[1].Opponent.ID).To(Equal(111))
+			Expect(resp.Series[1].Games).To(HaveLen(3))
+			Expect(resp.Series[1].Games[0].GamePk).To(Equal(3))
+			Expect(resp.Series[1].Games[1].GamePk).To(Equal(4))
+		})
+
+		It("should tally running, home and away records from completed games", func(ctx SpecContext) {
+			resp, err := buildTeamSchedule(147, dates)
+			Expect(err).To(BeNil())
+
+			Expect(resp.Series[0].Games[0].RunningRecord).To(Equal(Record{Wins: 0, Losses: 1, Pct: ".000"}))
+			Expect(resp.Series[0].Games[1].RunningRecord).To(Equal(Record{Wins: 1, Losses: 1, Pct: ".500"}))
+			Expect(resp.Record).To(Equal(Record{Wins: 2, Losses: 2, Pct: ".500"}))
+			Expect(resp.Home).To(Equal(Record{Wins: 1, Losses: 1, Pct: ".500"}))
+			Expect(resp.Away).To(Equal(Record{Wins: 1, Losses: 1, Pct: ".500"}))
+			Expect(resp.Series[1].Record).To(Equal(Record{Wins: 1, Losses: 1, Pct: ".500"}))
+		})
+	})
+
+	When("A suspended game is resumed on a later date", func() {
+		It("should count it once and only once it is completed", func(ctx SpecContext) {
+			suspended := newTestSeriesGame(1, "2021-04-01T17:05:00Z", 147, 141, 1, false)
+			suspended.Status.DetailedState = "Suspended: Rain"
+			// statsapi names a winner once the resumed game is completed
+			suspended.Teams.Away.IsWinner = true
+			resumed := newTestSeriesGame(1, "2021-04-02T17:05:00Z", 147, 141, 1, false)
+			resumed.Teams.Away.IsWinner = true
+			completedAgain := resumed
+			completedAgain.GameDate = "2021-04-02T20:05:00Z"
+
+			resp, err := buildTeamSchedule(147, []models.Date{
+				{Date: "2021-04-01", Games: []models.Game{suspended}},
+				{Date: "2021-04-02", Games: []models.Game{resumed}},
+				{Date: "2021-04-03", Games: []models.Game{completedAgain}},
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Series).To(HaveLen(1))
+			Expect(resp.Series[0].Games).To(HaveLen(3))
+			Expect(resp.Series[0].Games[0].RunningRecord).To(Equal(Record{Pct: ".000"}))
+			Expect(resp.Series[0].Games[1].RunningRecord).To(Equal(Record{Wins: 1, Pct: "1.000"}))
+			Expect(resp.Record).To(Equal(Record{Wins: 1, Pct: "1.000"}))
+			Expect(resp.Away).To(Equal(Record{Wins: 1, Pct: "1.000"}))
+			Expect(resp.Series[0].Record).To(Equal(Record{Wins: 1, Pct: "1.000"}))
+		})
+	})
+
+	When("We page a team's season schedule", func() {
+		var router *gin.Engine
+
+		BeforeEach(func() {
+			var teamResp models.TeamsResponse
+			setLock = new(sync.RWMutex)
+			err := json.Unmarshal([]byte(teamsAPIJSON), &teamResp)
+			if err != nil {
+				os.Exit(1)
+			}
+			createTeamsSet(teamResp)
+
+			dh2 := newTestSeriesGame(4, "2021-04-05T20:00:00Z", 111, 147, 2, false)
+			dh2.DoubleHeader = "Y"
+			dh2.Status.StartTimeTBD = true
+			dh1 := newTestSeriesGame(3, "2021-04-05T17:00:00Z", 111, 147, 2, true)
+			dh1.DoubleHeader = "Y"
+			getScheduleAPIResp = func(url string) (*models.ScheduleResponse, error) {
+				dates := []models.Date{
+					{Date: "2021-04-01", Games: []models.Game{newTestSeriesGame(1, "2021-04-01T17:05:00Z", 147, 141, 1, true)}},
+					{Date: "2021-04-02", Games: []models.Game{newTestSeriesGame(2, "2021-04-02T17:05:00Z", 147, 141, 1, false)}},
+					{Date: "2021-04-05", Games: []models.Game{dh2, dh1}},
+				}
+				for _, d := range dates {
+					for i := range d.Games {
+						d.Games[i].GameType = regularSeasonGameType
+					}
+				}
+				return &models.ScheduleResponse{Dates: dates}, nil
+			}
+
+			router = gin.New()
+			router.GET("/teams/:id/schedule", FieldSelection(TeamScheduleGame{}), GetTeamSchedule)
+		})
+
+		AfterEach(func() {
+			getScheduleAPIResp = fetchScheduleAPIResp
+		})
+
+		get := func(url string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+			return w
+		}
+
+		It("should select the fields of the team schedule's games", func(ctx SpecContext) {
+			w := get("/teams/147/schedule?season=2021&fields=gamePk,runningRecord.wins")
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+			var resp struct {
+				Series []struct {
+					Games []map[string]interface{} `json:"games"`
+				} `json:"series"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+			Expect(resp.Series[0].Games[0]).To(Equal(map[string]interface{}{
+				"gamePk": float64(1),
+				// the opening loss
+				"runningRecord": map[string]interface{}{"wins": float64(0)},
+			}))
+		})
+
+		It("should page the games across dates while tallying the whole season", func(ctx SpecContext) {
+			w := get("/teams/147/schedule?season=2021&limit=3")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var first TeamScheduleResponse
+			Expect(json.Unmarshal(w.Body.Bytes(), &first)).To(Succeed())
+			Expect(first.Record).To(Equal(Record{Wins: 2, Losses: 2, Pct: ".500"}))
+			Expect(first.Series).To(HaveLen(2))
+			Expect(first.Series[1].Games).To(HaveLen(1))
+			Expect(first.Series[1].Games[0].GamePk).To(Equal(3))
+			Expect(first.Next).NotTo(BeEmpty())
+
+			w = get(first.Next)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var second TeamScheduleResponse
+			Expect(json.Unmarshal(w.Body.Bytes(), &second)).To(Succeed())
+			Expect(second.Record).To(Equal(first.Record))
+			Expect(second.Series).To(HaveLen(1))
+			Expect(second.Series[0].Games).To(HaveLen(1))
+			Expect(second.Series[0].Games[0].GamePk).To(Equal(4))
+			Expect(second.Series[0].Games[0].RunningRecord).To(Equal(Record{Wins: 2, Losses: 2, Pct: ".500"}))
+			Expect(second.Next).To(BeEmpty())
+		})
+
+		It("should link a paged export to its next page", func(ctx SpecContext) {
+			w := get("/teams/147/schedule?season=2021&limit=2&format=ndjson")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Header().Get("Link")).To(MatchRegexp(`^</teams/147/schedule\?.*cursor=.*>; rel="next"$`))
+		})
+
+		It("should reject an invalid limit", func(ctx SpecContext) {
+			Expect(get("/teams/147/schedule?season=2021&limit=0").Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	// embed the IANA time zone database so tz lookups do not depend on the host
+	_ "time/tzdata"
+
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+const localGameTimeFmt = "3:04 PM MST"
+
+// parseTimeZone loads the IANA time zone requested by the tz query parameter,
+// an empty value returns a nil location
+func parseTimeZone(tz string) (*time.Location, error) {
+	if tz == "" {
+		return nil, nil
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone: %s", tz)
+	}
+	return loc, nil
+}
+
+// getScheduleForLocalDate returns a schedule holding a single date containing
+// every game whose first pitch falls on date in loc, a late west coast game
+// may belong to statsapi's next official date in an eastern time zone
+func getScheduleForLocalDate(date string, loc *time.Location) (*models.ScheduleResponse, error) {
+	day, err := time.ParseInLocation("2006-01-02", date, loc)
+	if err != nil {
+		return nil, errors.New("invalid date string")
+	}
+
+	startDate := day.AddDate(0, 0, -1).Format("2006-01-02")
+	endDate := day.AddDate(0, 0, 1).Format("2006-01-02")
+	schedResp, err := getDateRangeSchedule(startDate, endDate, nil, func(startDate string, endDate string) (*models.ScheduleResponse, error) {
+		return getRecordedScheduleAPIResp(fmt.Sprintf(scheduleRangeAPIFmtStr, startDate, endDate))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	localDate := models.Date{Date: date, Games: make([]models.Game, 0)}
+	for _, d := range schedResp.Dates {
+		for _, g := range d.Games {
+			if localGameDate(g, loc) == date {
+				localDate.Games = append(localDate.Games, g)
+			}
+		}
+	}
+	localDate.TotalGames = uint8(len(localDate.Games))
+	localDate.TotalItems = localDate.TotalGames
+
+	schedResp.Dates = []models.Date{localDate}
+	schedResp.TotalGames = localDate.TotalGames
+	schedResp.TotalItems = localDate.TotalItems
+	return schedResp, nil
+}
+
+// localGameDate returns the YYYY-MM-DD date of a game's first pitch in loc,
+// falling back to statsapi's official date when the start time is to be
+// determined, as gameDate is then a placeholder, or cannot be parsed
+func localGameDate(g models.Game, loc *time.Location) string {
+	if g.Status.StartTimeTBD && g.OfficialDate != "" {
+		return g.OfficialDate
+	}
+	t, err := time.Parse(time.RFC3339, g.GameDate)
+	if err != nil {
+		return g.OfficialDate
+	}
+	return t.In(loc).Format("2006-01-02")
+}
+
+// localizeGames populates each game's local start time in loc, or in its
+// venue's time zone when loc is nil
+func localizeGames(games []models.Game, loc *time.Location) {
+	for i := range games {
+		gameLoc := loc
+		if gameLoc == nil && games[i].Venue.TimeZone != nil {
+			venueLoc, err := time.LoadLocation(games[i].Venue.TimeZone.ID)
+			if err == nil {
+				gameLoc = venueLoc
+			}
+		}
+		if gameLoc == nil {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, games[i].GameDate)
+		if err != nil {
+			continue
+		}
+
+		local := t.In(gameLoc)
+		games[i].LocalGameDate = local.Format(time.RFC3339)
+		games[i].LocalTimeZone = gameLoc.String()
+		games[i].LocalGameTime = local.Format(localGameTimeFmt)
+		if games[i].Status.StartTimeTBD {
+			games[i].LocalGameTime = "TBD"
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+var _ = Describe("Localizing game times", Label("Timezone"), func() {
+	When("We parse the tz query parameter", func() {
+		Context("and it is empty", func() {
+			It("should return a nil location", func(ctx SpecContext) {
+				loc, err := parseTimeZone("")
+				Expect(err).To(BeNil())
+				Expect(loc).To(BeNil())
+			})
+		})
+		Context("and it is not an IANA time zone", func() {
+			It("should return an error", func(ctx SpecContext) {
+				_, err := parseTimeZone("America/Nowhere")
+				Expect(err).ToNot(BeNil())
+			})
+		})
+	})
+
+	When("We have a late west coast game", func() {
+		game := newTestGame(1, "2021-09-12T02:10:00Z", 147, 119, "F", "Dodger Stadium")
+		game.OfficialDate = "2021-09-11"
+
+		It("should belong to the date of first pitch in the requested zone", func(ctx SpecContext) {
+			loc, err := parseTimeZone("America/New_York")
+			Expect(err).To(BeNil())
+			Expect(localGameDate(game, loc)).To(Equal("2021-09-11"))
+
+			loc, err = parseTimeZone("Europe/London")
+			Expect(err).To(BeNil())
+			Expect(localGameDate(game, loc)).To(Equal("2021-09-12"))
+		})
+	})
+
+	When("We have a game whose start time is to be determined", func() {
+		// statsapi places TBD games at a placeholder time
+		game := newTestGame(1, "2021-09-12T03:33:00Z", 147, 111, "P", "Fenway Park")
+		game.OfficialDate = "2021-09-11"
+		game.Status.StartTimeTBD = true
+
+		It("should belong to its official date", func(ctx SpecContext) {
+			loc, err := parseTimeZone("Europe/London")
+			Expect(err).To(BeNil())
+			Expect(localGameDate(game, loc)).To(Equal("2021-09-11"))
+		})
+	})
+
+	When("We localize game start times", func() {
+		Context("and a time zone was requested", func() {
+			It("should render start times in the requested zone", func(ctx SpecContext) {
+				games := []models.Game{newTestGame(1, "2021-09-11T23:05:00Z", 147, 111, "P", "Fenway Park")}
+				loc, err := parseTimeZone("America/Los_Angeles")
+				Expect(err).To(BeNil())
+
+				localizeGames(games, loc)
+				Expect(games[0].LocalGameDate).To(Equal("2021-09-11T16:05:00-07:00"))
+				Expect(games[0].LocalGameTime).To(Equal("4:05 PM PDT"))
+				Expect(games[0].LocalTimeZone).To(Equal("America/Los_Angeles"))
+			})
+		})
+		Context("and no time zone was requested", func() {
+			It("should render start times in the venue's time zone", func(ctx SpecContext) {
+				games := []models.Game{newTestGame(1, "2021-09-11T23:05:00Z", 147, 111, "P", "Fenway Park")}
+				games[0].Venue.TimeZone = &models.VenueTimeZone{ID: "America/New_York", Offset: -4, Tz: "EDT"}
+
+				localizeGames(games, nil)
+				Expect(games[0].LocalGameTime).To(Equal("7:05 PM EDT"))
+				Expect(games[0].LocalTimeZone).To(Equal("America/New_York"))
+			})
+		})
+		Context("and the start time is to be determined", func() {
+			It("should not render a start time", func(ctx SpecContext) {
+				games := []models.Game{newTestGame(1, "2021-09-11T23:05:00Z", 147, 111, "P", "Fenway Park")}
+				games[0].Status.StartTimeTBD = true
+				loc, err := parseTimeZone("America/New_York")
+				Expect(err).To(BeNil())
+
+				localizeGames(games, loc)
+				Expect(games[0].LocalGameTime).To(Equal("TBD"))
+			})
+		})
+	})
+})
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+// structs for /venues API responses
+type VenuesResponse struct {
+	Venues []VenueResponse `json:"venues"`
+}
+
+type VenueResponse struct {
+	models.Venue
+	Teams []models.Team `json:"teams"`
+}
+
+type VenueScheduleResponse struct {
+	Venue     models.Venue  `json:"venue"`
+	StartDate string        `json:"startDate"`
+	EndDate   string        `json:"endDate"`
+	Dates     []models.Date `json:"dates"`
+	Next      string        `json:"next,omitempty"`
+}
+
+// getVenues returns the home venues of the clubs in the team registry ordered
+// by name, along with the clubs that call them home
+func getVenues() []VenueResponse {
+	venuesById := make(map[int]*VenueResponse)
+	for _, team := range getTeams() {
+		if team.Venue == nil {
+			continue
+		}
+		venue, ok := venuesById[team.Venue.ID]
+		if !ok {
+			venue = &VenueResponse{Venue: *team.Venue, Teams: make([]models.Team, 0)}
+			venuesById[team.Venue.ID] = venue
+		}
+		venue.Teams = append(venue.Teams, models.Team{ID: team.ID, Name: team.Name, Link: team.Link})
+	}
+
+	venues := make([]VenueResponse, 0, len(venuesById))
+	for _, venue := range venuesById {
+		venues = append(venues, *venue)
+	}
+	sort.Slice(venues, func(i, j int) bool {
+		return venues[i].Name < venues[j].Name
+	})
+	return venues
+}
+
+// markNeutralSites flags games that are not played at the home team's usual
+// venue that season, ie. the London Series or the Field of Dreams game, so that
+// games at a club's former park are not flagged. Spring training games are
+// compared against the home team's spring venue, exhibition games have no
+// usual venue and are never flagged
+func markNeutralSites(games []models.Game) {
+	seasons := make(map[string]map[int]models.Team)
+	for i := range games {
+		homeTeams, ok := seasons[games[i].Season]
+		if !ok {
+			homeTeams = make(map[int]models.Team)
+			if season, err := strconv.Atoi(games[i].Season); err == nil {
+				for _, team := range getSeasonTeams(season) {
+					homeTeams[team.ID] = team
+				}
+			}
+			seasons[games[i].Season] = homeTeams
+		}
+
+		home, ok := homeTeams[games[i].Teams.Home.Team.ID]
+		if !ok {
+			home, ok = getTeam(games[i].Teams.Home.Team.ID)
+			if !ok {
+				continue
+			}
+		}
+
+		usual := home.Venue
+		switch games[i].GameType {
+		case "S":
+			usual = home.SpringVenue
+		case "E":
+			continue
+		}
+		if usual == nil {
+			continue
+		}
+		games[i].IsNeutralSite = games[i].Venue.ID != usual.ID
+	}
+}
+
+// validateDateRange ensures startDate and endDate are YYYY-MM-DD dates with
+// startDate not after endDate
+func validateDateRange(startDate string, endDate string) error {
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return errors.New("invalid startDate string")
+	}
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return errors.New("invalid endDate string")
+	}
+	if start.After(end) {
+		return errors.New("startDate must not be after endDate")
+	}
+	return nil
+}
+
+// GetVenues serves the /venues API which allows a client to receive the home
+// venues of every MLB club
+func GetVenues(c *gin.Context) {
+	c.JSON(http.StatusOK, VenuesResponse{Venues: getVenues()})
+}
+
+// GetVenueSchedule serves the /venues/{id}/schedule?startDate=<YYYY-MM-DD>&endDate=<YYYY-MM-DD>
+// API which allows a client to receive the games played at a venue between two dates,
+// an optional limit=<n> pages the games across dates
+func GetVenueSchedule(c *gin.Context) {
+	format, err := negotiateFormat(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	startDate := c.Query("startDate")
+	endDate := c.Query("endDate")
+	err = validateDateRange(startDate, endDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	limit, cursor, err := parsePageParameters(c.Query("limit"), c.Query("cursor"), "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	playedAt := func(g models.Game) bool {
+		return g.Venue.ID == id
+	}
+	schedResp, err := getDateRangeSchedule(startDate, endDate, playedAt, func(startDate string, endDate string) (*models.ScheduleResponse, error) {
+		return getScheduleAPIResp(fmt.Sprintf(venueScheduleAPIFmtStr, id, startDate, endDate))
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ScheduleErrorResponse{Message: err.Error(), Timestamp: time.Now().UTC().String()})
+		return
+	}
+
+	resp := VenueScheduleResponse{Venue: models.Venue{ID: id}, StartDate: startDate, EndDate: endDate, Dates: make([]models.Date, 0)}
+	if venue, ok := getStoredVenue(id); ok {
+		// named even when no games were played there in the range
+		resp.Venue = venue
+	}
+	for _, d := range schedResp.Dates {
+		sortGames(d.Games, byFirstPitch)
+		localizeGames(d.Games, nil)
+		markNeutralSites(d.Games)
+		if len(d.Games) > 0 {
+			resp.Venue = d.Games[0].Venue
+		}
+		resp.Dates = append(resp.Dates, d)
+	}
+
+	var next *pageCursor
+	resp.Dates, next = paginateDates(resp.Dates, limit, cursor)
+	if format != formatJSON {
+		// exports carry the next page's link in a header, as they have no envelope
+		if next != nil {
+			c.Header("Link", fmt.Sprintf("<%s>; rel=\"next\"", nextLink(c, *next)))
+		}
+		writeGames(c, format, resp.Dates)
+		return
+	}
+	if next != nil {
+		resp.Next = nextLink(c, *next)
+	}
+	c.JSON(http.StatusOK, resp)
+}
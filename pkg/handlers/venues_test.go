@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+var _ = Describe("Processing Venue requests", Label("Venues"), func() {
+	BeforeEach(func() {
+		var teamResp models.TeamsResponse
+		setLock = new(sync.RWMutex)
+		err := json.Unmarshal([]byte(teamsAPIJSON), &teamResp)
+		if err != nil {
+			os.Exit(1)
+		}
+		createTeamsSet(teamResp)
+	})
+
+	When("We list venues", func() {
+		It("should return each club's home venue ordered by name", func(ctx SpecContext) {
+			venues := getVenues()
+			Expect(venues).To(HaveLen(30))
+			Expect(venues[0].Name).To(Equal("American Family Field"))
+
+			for _, venue := range venues {
+				if venue.ID == 3 {
+					Expect(venue.Name).To(Equal("Fenway Park"))
+					Expect(venue.Teams).To(HaveLen(1))
+					Expect(venue.Teams[0].ID).To(Equal(111))
+				}
+			}
+		})
+	})
+
+	When("We mark neutral site games", func() {
+		home := newTestGame(1, "2021-08-12T23:15:00Z", 147, 111, "F", "Fenway Park")
+		home.Venue.ID = 3
+		fieldOfDreams := newTestGame(2, "2021-08-12T23:15:00Z", 147, 145, "F", "Field of Dreams")
+		fieldOfDreams.Venue.ID = 5445
+
+		It("should only flag games away from the home team's venue", func(ctx SpecContext) {
+			games := []models.Game{home, fieldOfDreams}
+			markNeutralSites(games)
+			Expect(games[0].IsNeutralSite).To(BeFalse())
+			Expect(games[1].IsNeutralSite).To(BeTrue())
+		})
+
+		It("should compare spring training games against the home team's spring venue", func(ctx SpecContext) {
+			spring := newTestGame(3, "2021-03-12T17:05:00Z", 147, 111, "F", "JetBlue Park")
+			spring.GameType = "S"
+			spring.Venue.ID = 4309
+			atFenway := newTestGame(4, "2021-03-13T17:05:00Z", 147, 111, "F", "Fenway Park")
+			atFenway.GameType = "S"
+			atFenway.Venue.ID = 3
+			exhibition := newTestGame(5, "2021-03-14T17:05:00Z", 147, 111, "F", "Estadio Quisqueya")
+			exhibition.GameType = "E"
+			exhibition.Venue.ID = 2701
+
+			games := []models.Game{spring, atFenway, exhibition}
+			markNeutralSites(games)
+			Expect(games[0].IsNeutralSite).To(BeFalse())
+			Expect(games[1].IsNeutralSite).To(BeTrue())
+			Expect(games[2].IsNeutralSite).To(BeFalse())
+		})
+	})
+
+	When("We mark neutral site games of a past season", func() {
+		AfterEach(func() {
+			getSeasonTeamsAPIResp = fetchSeasonTeamsAPIResp
+			seasonTeamsCache.lock.Lock()
+			seasonTeamsCache.teams = make(map[int][]models.Team)
+			seasonTeamsCache.lock.Unlock()
+		})
+
+		It("should compare games against the home team's venue that season", func(ctx SpecContext) {
+			getSeasonTeamsAPIResp = func(season int) (*models.TeamsResponse, error) {
+				var teamResp models.TeamsResponse
+				Expect(json.Unmarshal([]byte(teamsAPIJSON), &teamResp)).To(Succeed())
+				for i, team := range teamResp.Teams {
+					if team.ID == 140 && season == 2019 {
+						// the Rangers played at Globe Life Park until 2019
+						teamResp.Teams[i].Venue = &models.Venue{ID: 13, Name: "Globe Life Park in Arlington"}
+					}
+				}
+				return &teamResp, nil
+			}
+
+			formerPark := newTestGame(1, "2019-09-29T19:05:00Z", 136, 140, "F", "Globe Life Park in Arlington")
+			formerPark.Season = "2019"
+			formerPark.Venue.ID = 13
+			newPark := newTestGame(2, "2021-09-29T19:05:00Z", 136, 140, "F", "Globe Life Field")
+			newPark.Season = "2021"
+			newPark.Venue.ID = 5325
+			oldParkNow := newTestGame(3, "2021-09-30T19:05:00Z", 136, 140, "F", "Globe Life Park in Arlington")
+			oldParkNow.Season = "2021"
+			oldParkNow.Venue.ID = 13
+
+			games := []models.Game{formerPark, newPark, oldParkNow}
+			markNeutralSites(games)
+			Expect(games[0].IsNeutralSite).To(BeFalse())
+			Expect(games[1].IsNeutralSite).To(BeFalse())
+			Expect(games[2].IsNeutralSite).To(BeTrue())
+		})
+	})
+
+	When("We validate a date range", func() {
+		It("should require startDate to not be after endDate", func(ctx SpecContext) {
+			Expect(validateDateRange("2021-08-01", "2021-08-07")).To(BeNil())
+			Expect(validateDateRange("2021-08-07", "2021-08-07")).To(BeNil())
+			Expect(validateDateRange("2021-08-08", "2021-08-07")).ToNot(BeNil())
+			Expect(validateDateRange("2021-08-q1", "2021-08-07")).ToNot(BeNil())
+		})
+	})
+})
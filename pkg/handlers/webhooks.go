@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+const (
+	webhookSignatureHeader = "X-Webhook-Signature"
+	webhookEventHeader     = "X-Webhook-Event"
+	webhookDeliveryHeader  = "X-Webhook-Delivery"
+	// maxDeadLetters bounds the dead letter list, the oldest deliveries are dropped first
+	maxDeadLetters = 1000
+	// webhookWorkers deliver payloads from a queue of at most webhookQueueSize,
+	// deliveries raised while the queue is full are dead lettered
+	webhookWorkers   = 8
+	webhookQueueSize = 1000
+)
+
+var (
+	webhookClient      = newWebhookClient()
+	webhookMaxAttempts = 5
+	// webhookBackoff is the delay before the first retry, doubling for each subsequent retry
+	webhookBackoff = time.Second
+)
+
+// structs for webhook deliveries and the /subscriptions/deadletters API response
+type WebhookPayload struct {
+	ID             string        `json:"id"`
+	Event          string        `json:"event"`
+	SubscriptionID string        `json:"subscriptionId"`
+	TeamID         int           `json:"teamId"`
+	OccurredAt     string        `json:"occurredAt"`
+	Game           models.Game   `json:"game"`
+	Changes        []FieldChange `json:"changes"`
+}
+
+type DeadLetter struct {
+	Payload     WebhookPayload `json:"payload"`
+	CallbackURL string         `json:"callbackUrl"`
+	Attempts    int            `json:"attempts"`
+	LastError   string         `json:"lastError"`
+	FailedAt    string         `json:"failedAt"`
+}
+
+type DeadLettersResponse struct {
+	DeadLetters []DeadLetter `json:"deadLetters"`
+}
+
+var deadLetters []DeadLetter
+var deadLetterLock = new(sync.RWMutex)
+
+// pendingWebhook is a delivery waiting for a worker
+type pendingWebhook struct {
+	sub     Subscription
+	payload WebhookPayload
+}
+
+var webhookQueue = make(chan pendingWebhook, webhookQueueSize)
+var startWebhookWorkers sync.Once
+
+// publicIP reports whether ip may be connected to on behalf of a subscriber,
+// loopback, private and link-local addresses would let a subscriber reach
+// services only this server can, ie. a cloud metadata endpoint
+func publicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+// newWebhookClient returns a client that refuses to connect to addresses that
+// are not public, checked once a callback's host has been resolved so that a
+// name resolving to an internal address, or a redirect to one, is refused too
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+				return fmt.Errorf("callback address %s is not allowed", host)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: &http.Transport{DialContext: dialer.DialContext, TLSHandshakeTimeout: 5 * time.Second},
+	}
+}
+
+func addDeadLetter(deadLetter DeadLetter) {
+	deadLetterLock.Lock()
+	deadLetters = append(deadLetters, deadLetter)
+	if len(deadLetters) > maxDeadLetters {
+		deadLetters = deadLetters[len(deadLetters)-maxDeadLetters:]
+	}
+	deadLetterLock.Unlock()
+}
+
+func getDeadLetters() []DeadLetter {
+	deadLetterLock.RLock()
+	defer deadLetterLock.RUnlock()
+	return append(make([]DeadLetter, 0, len(deadLetters)), deadLetters...)
+}
+
+// gameEvents lists the webhook events raised by a detected game change
+func gameEvents(change GameChange) []string {
+	before, after := change.before, change.after
+	events := make([]string, 0)
+	if before.GamePk == 0 || after.GamePk == 0 {
+		// newly scheduled and removed games have no state transition
+		return events
+	}
+
+	if before.Status.AbstractGameState == "Preview" && after.Status.AbstractGameState == "Live" {
+		events = append(events, eventGameLive)
+	}
+	if after.Status.DetailedState == "Postponed" && before.Status.DetailedState != "Postponed" {
+		events = append(events, eventGamePostponed)
+	} else if before.Status.AbstractGameState == "Live" && after.Status.AbstractGameState == "Final" {
+		events = append(events, eventGameFinal)
+	}
+	if before.GameDate != after.GameDate {
+		events = append(events, eventStartTimeChanged)
+	}
+	return events
+}
+
+// signPayload computes the hex encoded HMAC-SHA256 of body keyed by secret
+func signPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// notifySubscribers delivers a webhook to every subscription matching the
+// events raised by changes, deliveries are queued for the webhook workers
+func notifySubscribers(changes []GameChange) {
+	for _, change := range changes {
+		teamIds := []int{change.after.Teams.Home.Team.ID, change.after.Teams.Away.Team.ID}
+		for _, event := range gameEvents(change) {
+			for _, sub := range subscriptions.matching(event, teamIds...) {
+				id, err := randomHex(16)
+				if err != nil {
+					fmt.Printf("got err when creating webhook delivery: %s\n", err.Error())
+					continue
+				}
+
+				payload := WebhookPayload{
+					ID:             id,
+					Event:          event,
+					SubscriptionID: sub.ID,
+					TeamID:         sub.TeamID,
+					OccurredAt:     change.DetectedAt,
+					Game:           change.after,
+					Changes:        change.Changes,
+				}
+				queueWebhook(sub, payload)
+			}
+		}
+	}
+}
+
+// queueWebhook hands a delivery to the webhook workers, starting them the
+// first time, without waiting for a worker to be free
+func queueWebhook(sub Subscription, payload WebhookPayload) {
+	startWebhookWorkers.Do(func() {
+		for i := 0; i < webhookWorkers; i++ {
+			go func() {
+				for pending := range webhookQueue {
+					deliverWebhook(pending.sub, pending.payload)
+				}
+			}()
+		}
+	})
+
+	select {
+	case webhookQueue <- pendingWebhook{sub: sub, payload: payload}:
+	default:
+		addDeadLetter(DeadLetter{Payload: payload, CallbackURL: sub.CallbackURL, LastError: "delivery queue is full", FailedAt: time.Now().UTC().Format(time.RFC3339Nano)})
+	}
+}
+
+// deliverWebhook POSTs a signed payload to a subscription's callback, retrying
+// with exponential backoff and dead lettering the payload once attempts run out
+func deliverWebhook(sub Subscription, payload WebhookPayload) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		addDeadLetter(DeadLetter{Payload: payload, CallbackURL: sub.CallbackURL, LastError: err.Error(), FailedAt: time.Now().UTC().Format(time.RFC3339Nano)})
+		return
+	}
+	signature := signPayload(sub.Secret, body)
+
+	backoff := webhookBackoff
+	for attempt := 1; ; attempt++ {
+		err = postWebhook(sub.CallbackURL, payload, body, signature)
+		if err == nil {
+			return
+		}
+		if attempt >= webhookMaxAttempts {
+			addDeadLetter(DeadLetter{Payload: payload, CallbackURL: sub.CallbackURL, Attempts: attempt, LastError: err.Error(), FailedAt: time.Now().UTC().Format(time.RFC3339Nano)})
+			return
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func postWebhook(callbackURL string, payload WebhookPayload, body []byte, signature string) error {
+	req, err := http.NewRequest(http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookSignatureHeader, signature)
+	req.Header.Set(webhookEventHeader, payload.Event)
+	req.Header.Set(webhookDeliveryHeader, payload.ID)
+
+	res, err := webhookClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("callback responded with status %d", res.StatusCode)
+	}
+	return nil
+}
+
+// GetDeadLetters serves GET /subscriptions/deadletters which lists the webhook
+// deliveries that failed after every retry, for the subscriptions registered
+// with the secret given as a bearer token
+func GetDeadLetters(c *gin.Context) {
+	secret, ok := subscriptionSecret(c)
+	if !ok {
+		return
+	}
+
+	owned := make(map[string]bool)
+	for _, sub := range subscriptions.owned(secret) {
+		owned[sub.ID] = true
+	}
+	ownedDeadLetters := make([]DeadLetter, 0)
+	for _, deadLetter := range getDeadLetters() {
+		if owned[deadLetter.Payload.SubscriptionID] {
+			ownedDeadLetters = append(ownedDeadLetters, deadLetter)
+		}
+	}
+	c.JSON(http.StatusOK, DeadLettersResponse{DeadLetters: ownedDeadLetters})
+}
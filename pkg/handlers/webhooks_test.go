@@ -0,0 +1,300 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+type webhookDelivery struct {
+	header http.Header
+	body   []byte
+}
+
+var _ = Describe("Delivering webhooks", Label("Webhooks"), func() {
+	var deliveries chan webhookDelivery
+	var receiver *httptest.Server
+	var failures int
+	var failuresLock sync.Mutex
+
+	BeforeEach(func() {
+		var teamResp models.TeamsResponse
+		setLock = new(sync.RWMutex)
+		err := json.Unmarshal([]byte(teamsAPIJSON), &teamResp)
+		if err != nil {
+			os.Exit(1)
+		}
+		createTeamsSet(teamResp)
+
+		subscriptions = newSubscriptionRegistry()
+		deadLetters = nil
+		webhookBackoff = time.Millisecond
+		// the receiver listens on a loopback address, which subscribers may not reach
+		webhookClient = &http.Client{Timeout: time.Second}
+		failures = 0
+		deliveries = make(chan webhookDelivery, 10)
+		receiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			failuresLock.Lock()
+			defer failuresLock.Unlock()
+			if failures > 0 {
+				failures--
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			deliveries <- webhookDelivery{header: r.Header, body: body}
+		}))
+	})
+
+	AfterEach(func() {
+		receiver.Close()
+		subscriptions = newSubscriptionRegistry()
+		deadLetters = nil
+		webhookBackoff = time.Second
+		webhookClient = newWebhookClient()
+	})
+
+	When("We register a subscription", func() {
+		Context("with a valid request", func() {
+			It("should generate an ID and secret", func(ctx SpecContext) {
+				router := gin.New()
+				router.POST("/subscriptions", CreateSubscription)
+				body := `{"teamId": 147, "events": ["gameLive"], "callbackUrl": "https://example.com/hooks/mlb"}`
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/subscriptions", strings.NewReader(body)))
+				Expect(w.Code).To(Equal(http.StatusCreated))
+
+				var sub Subscription
+				Expect(json.Unmarshal(w.Body.Bytes(), &sub)).To(Succeed())
+				Expect(sub.ID).ToNot(BeEmpty())
+				Expect(sub.Secret).ToNot(BeEmpty())
+				Expect(subscriptions.list()).To(HaveLen(1))
+			})
+		})
+		Context("with an unknown event", func() {
+			It("should return an error", func(ctx SpecContext) {
+				err := validateSubscriptionRequest(SubscriptionRequest{TeamID: 147, Events: []string{"gameTied"}, CallbackURL: receiver.URL})
+				Expect(err).ToNot(BeNil())
+			})
+		})
+		Context("with an invalid callback", func() {
+			It("should return an error", func(ctx SpecContext) {
+				err := validateSubscriptionRequest(SubscriptionRequest{TeamID: 147, Events: []string{eventGameLive}, CallbackURL: "ftp://example.com"})
+				Expect(err).ToNot(BeNil())
+			})
+		})
+		Context("with a callback on an internal address", func() {
+			It("should return an error", func(ctx SpecContext) {
+				for _, callback := range []string{
+					"http://localhost:8080/hook",
+					"http://127.0.0.1/hook",
+					"http://10.0.0.8/hook",
+					"http://192.168.1.1/hook",
+					"http://169.254.169.254/latest/meta-data",
+					"http://[::1]/hook",
+				} {
+					err := validateSubscriptionRequest(SubscriptionRequest{TeamID: 147, Events: []string{eventGameLive}, CallbackURL: callback})
+					Expect(err).ToNot(BeNil(), callback)
+				}
+			})
+		})
+	})
+
+	When("We manage subscriptions", func() {
+		var router *gin.Engine
+
+		BeforeEach(func() {
+			router = gin.New()
+			router.GET("/subscriptions", GetSubscriptions)
+			router.DELETE("/subscriptions/:id", DeleteSubscription)
+			router.GET("/subscriptions/deadletters", GetDeadLetters)
+			subscriptions.add(Subscription{ID: "mine", TeamID: 147, Events: []string{eventGameFinal}, CallbackURL: receiver.URL, Secret: "shh", CreatedAt: "1"})
+			subscriptions.add(Subscription{ID: "theirs", TeamID: 111, Events: []string{eventGameFinal}, CallbackURL: receiver.URL, Secret: "psst", CreatedAt: "2"})
+			addDeadLetter(DeadLetter{Payload: WebhookPayload{SubscriptionID: "mine"}})
+			addDeadLetter(DeadLetter{Payload: WebhookPayload{SubscriptionID: "theirs"}})
+		})
+
+		do := func(method string, url string, secret string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(method, url, nil)
+			if secret != "" {
+				req.Header.Set("Authorization", "Bearer "+secret)
+			}
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		It("should require the subscription secret", func(ctx SpecContext) {
+			Expect(do(http.MethodGet, "/subscriptions", "").Code).To(Equal(http.StatusUnauthorized))
+			Expect(do(http.MethodGet, "/subscriptions/deadletters", "").Code).To(Equal(http.StatusUnauthorized))
+			Expect(do(http.MethodDelete, "/subscriptions/mine", "").Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should only list the subscriptions registered with the secret, without it", func(ctx SpecContext) {
+			w := do(http.MethodGet, "/subscriptions", "shh")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).NotTo(ContainSubstring("secret"))
+			var resp SubscriptionsResponse
+			Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+			Expect(resp.Subscriptions).To(HaveLen(1))
+			Expect(resp.Subscriptions[0].ID).To(Equal("mine"))
+
+			var deadLetters DeadLettersResponse
+			Expect(json.Unmarshal(do(http.MethodGet, "/subscriptions/deadletters", "shh").Body.Bytes(), &deadLetters)).To(Succeed())
+			Expect(deadLetters.DeadLetters).To(HaveLen(1))
+			Expect(deadLetters.DeadLetters[0].Payload.SubscriptionID).To(Equal("mine"))
+		})
+
+		It("should only delete a subscription given its secret", func(ctx SpecContext) {
+			Expect(do(http.MethodDelete, "/subscriptions/theirs", "shh").Code).To(Equal(http.StatusForbidden))
+			Expect(do(http.MethodDelete, "/subscriptions/missing", "shh").Code).To(Equal(http.StatusNotFound))
+			Expect(do(http.MethodDelete, "/subscriptions/mine", "shh").Code).To(Equal(http.StatusNoContent))
+			Expect(subscriptions.list()).To(HaveLen(1))
+			Expect(subscriptions.list()[0].ID).To(Equal("theirs"))
+		})
+	})
+
+	When("We derive events from a game change", func() {
+		before := newTestGame(1, "2021-09-11T23:05:00Z", 147, 111, "P", "Fenway Park")
+		before.Status.AbstractGameState = "Preview"
+		before.Status.DetailedState = "Scheduled"
+
+		It("should raise gameLive when a game starts", func(ctx SpecContext) {
+			after := before
+			after.Status.AbstractGameState = "Live"
+			after.Status.DetailedState = "In Progress"
+			Expect(gameEvents(GameChange{before: before, after: after})).To(Equal([]string{eventGameLive}))
+		})
+
+		It("should raise gamePostponed and startTimeChanged when a game is postponed to another day", func(ctx SpecContext) {
+			after := before
+			after.GameDate = "2021-09-12T17:05:00Z"
+			after.Status.AbstractGameState = "Final"
+			after.Status.DetailedState = "Postponed"
+			Expect(gameEvents(GameChange{before: before, after: after})).To(Equal([]string{eventGamePostponed, eventStartTimeChanged}))
+		})
+	})
+
+	When("A subscribed team's game is moved to another date", func() {
+		var schedule map[string][]models.Game
+
+		BeforeEach(func() {
+			snapshots = newScheduleSnapshots()
+			schedule = make(map[string][]models.Game)
+			getScheduleAPIResp = func(apiURL string) (*models.ScheduleResponse, error) {
+				u, err := url.Parse(apiURL)
+				if err != nil {
+					return nil, err
+				}
+				date := u.Query().Get("date")
+				return &models.ScheduleResponse{Dates: []models.Date{{Date: date, Games: schedule[date]}}}, nil
+			}
+			subscriptions.add(Subscription{ID: "sub", TeamID: 111, Events: []string{eventStartTimeChanged}, CallbackURL: receiver.URL, Secret: "shh"})
+		})
+
+		AfterEach(func() {
+			snapshots = newScheduleSnapshots()
+			getScheduleAPIResp = fetchScheduleAPIResp
+		})
+
+		It("should POST a startTimeChanged payload once the new date is fetched", func(ctx SpecContext) {
+			today := time.Now().UTC()
+			date, nextDate := today.Format("2006-01-02"), today.AddDate(0, 0, 1).Format("2006-01-02")
+			game := newTestGame(1, date+"T23:05:00Z", 147, 111, "P", "Fenway Park")
+			game.Status.AbstractGameState = "Preview"
+			schedule[date] = []models.Game{game}
+			_, err := getRecordedScheduleAPIResp(fmt.Sprintf(scheuldeAPIFmtStr, date))
+			Expect(err).To(BeNil())
+
+			game.GameDate = nextDate + "T17:05:00Z"
+			schedule[date] = nil
+			schedule[nextDate] = []models.Game{game}
+			for _, d := range []string{date, nextDate} {
+				_, err = getRecordedScheduleAPIResp(fmt.Sprintf(scheuldeAPIFmtStr, d))
+				Expect(err).To(BeNil())
+			}
+
+			var delivery webhookDelivery
+			Eventually(deliveries).Should(Receive(&delivery))
+			Expect(delivery.header.Get(webhookEventHeader)).To(Equal(eventStartTimeChanged))
+			var payload WebhookPayload
+			Expect(json.Unmarshal(delivery.body, &payload)).To(Succeed())
+			Expect(payload.Game.GameDate).To(Equal(nextDate + "T17:05:00Z"))
+			Expect(payload.Changes).To(ContainElement(FieldChange{Field: "gameDate", Before: date + "T23:05:00Z", After: nextDate + "T17:05:00Z"}))
+			Consistently(deliveries, 50*time.Millisecond).ShouldNot(Receive())
+		})
+	})
+
+	When("A subscribed team's game goes final", func() {
+		var change GameChange
+
+		BeforeEach(func() {
+			before := newTestGame(1, "2021-09-11T23:05:00Z", 147, 111, "L", "Fenway Park")
+			before.Status.AbstractGameState = "Live"
+			after := before
+			after.Status.AbstractGameState = "Final"
+			after.Status.DetailedState = "Final"
+			change = GameChange{GamePk: 1, before: before, after: after, Changes: diffGame(before, after)}
+
+			subscriptions.add(Subscription{ID: "sub", TeamID: 111, Events: []string{eventGameFinal}, CallbackURL: receiver.URL, Secret: "shh"})
+			subscriptions.add(Subscription{ID: "other", TeamID: 141, Events: []string{eventGameFinal}, CallbackURL: receiver.URL, Secret: "shh"})
+		})
+
+		It("should POST a signed payload to the subscriber", func(ctx SpecContext) {
+			notifySubscribers([]GameChange{change})
+
+			var delivery webhookDelivery
+			Eventually(deliveries).Should(Receive(&delivery))
+			Expect(delivery.header.Get(webhookEventHeader)).To(Equal(eventGameFinal))
+			Expect(delivery.header.Get(webhookSignatureHeader)).To(Equal(signPayload("shh", delivery.body)))
+
+			var payload WebhookPayload
+			Expect(json.Unmarshal(delivery.body, &payload)).To(Succeed())
+			Expect(payload.SubscriptionID).To(Equal("sub"))
+			Expect(payload.Game.GamePk).To(Equal(1))
+			Consistently(deliveries, 50*time.Millisecond).ShouldNot(Receive())
+		})
+
+		It("should retry failed deliveries", func(ctx SpecContext) {
+			failuresLock.Lock()
+			failures = webhookMaxAttempts - 1
+			failuresLock.Unlock()
+
+			notifySubscribers([]GameChange{change})
+			Eventually(deliveries).Should(Receive())
+			Expect(getDeadLetters()).To(BeEmpty())
+		})
+
+		It("should dead letter deliveries that fail every attempt", func(ctx SpecContext) {
+			failuresLock.Lock()
+			failures = webhookMaxAttempts
+			failuresLock.Unlock()
+
+			notifySubscribers([]GameChange{change})
+			Eventually(getDeadLetters).Should(HaveLen(1))
+			Expect(getDeadLetters()[0].Attempts).To(Equal(webhookMaxAttempts))
+			Expect(deliveries).ShouldNot(Receive())
+		})
+
+		It("should refuse to connect to an internal address", func(ctx SpecContext) {
+			webhookClient = newWebhookClient()
+
+			notifySubscribers([]GameChange{change})
+			Eventually(getDeadLetters).Should(HaveLen(1))
+			Expect(getDeadLetters()[0].LastError).To(ContainSubstring("callback address 127.0.0.1 is not allowed"))
+			Expect(deliveries).ShouldNot(Receive())
+		})
+	})
+})
@@ -5,11 +5,35 @@ type SpringLeagueTeam struct {
 	Name string `json:"name"`
 }
 
+type League struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Link string `json:"link"`
+}
+
+type Division struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Link string `json:"link"`
+}
+
 type Team struct {
-	SpringLeague SpringLeagueTeam `json:"springLeague,omitempty"`
-	ID           int              `json:"id"`
-	Name         string           `json:"name"`
-	Link         string           `json:"link"`
+	SpringLeague  SpringLeagueTeam `json:"springLeague,omitempty"`
+	ID            int              `json:"id"`
+	Name          string           `json:"name"`
+	Link          string           `json:"link"`
+	Abbreviation  string           `json:"abbreviation,omitempty"`
+	TeamName      string           `json:"teamName,omitempty"`
+	ShortName     string           `json:"shortName,omitempty"`
+	TeamCode      string           `json:"teamCode,omitempty"`
+	FileCode      string           `json:"fileCode,omitempty"`
+	ClubName      string           `json:"clubName,omitempty"`
+	FranchiseName string           `json:"franchiseName,omitempty"`
+	LocationName  string           `json:"locationName,omitempty"`
+	League        *League          `json:"league,omitempty"`
+	Division      *Division        `json:"division,omitempty"`
+	Venue         *Venue           `json:"venue,omitempty"`
+	SpringVenue   *Venue           `json:"springVenue,omitempty"`
 }
 
 type TeamsResponse struct {
@@ -18,7 +42,7 @@ type TeamsResponse struct {
 }
 
 type LeagueRecord struct {
-	Wins   uint8  `json"wins"`
+	Wins   uint8  `json:"wins"`
 	Losses uint8  `json:"losses"`
 	Pct    string `json:"pct"`
 }
@@ -32,9 +56,19 @@ type ScheduleTeam struct {
 	SeriesNumber uint8        `json:"seriesNumber"`
 }
 
+// Perspective describes a game from the point of view of one of its teams
+type Perspective struct {
+	Opponent      Team         `json:"opponent"`
+	HomeAway      string       `json:"homeAway"`
+	Score         uint8        `json:"score"`
+	OpponentScore uint8        `json:"opponentScore"`
+	Result        string       `json:"result,omitempty"`
+	Record        LeagueRecord `json:"record"`
+}
+
 type Teams struct {
 	Away ScheduleTeam `json:"away"`
-	Home ScheduleTeam `json"home"`
+	Home ScheduleTeam `json:"home"`
 }
 
 type Status struct {
@@ -46,10 +80,17 @@ type Status struct {
 	StartTimeTBD      bool   `json:"startTimeTBD"`
 }
 
+type VenueTimeZone struct {
+	ID     string `json:"id"`
+	Offset int    `json:"offset"`
+	Tz     string `json:"tz"`
+}
+
 type Venue struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
-	Link string `json:"link"`
+	ID       int            `json:"id"`
+	Name     string         `json:"name"`
+	Link     string         `json:"link"`
+	TimeZone *VenueTimeZone `json:"timeZone,omitempty"`
 }
 
 type Content struct {
@@ -85,6 +126,16 @@ type Game struct {
 	RecordSource           string  `json:"recordSource"`
 	IfNecessary            string  `json:"ifNecessary"`
 	IfNecessaryDescription string  `json:"ifNecessaryDescription"`
+
+	// localized start time, computed by this service rather than statsapi
+	LocalGameDate string `json:"localGameDate,omitempty"`
+	LocalGameTime string `json:"localGameTime,omitempty"`
+	LocalTimeZone string `json:"localTimeZone,omitempty"`
+
+	// requested team's view of the game, computed by this service rather than statsapi
+	Perspective *Perspective `json:"perspective,omitempty"`
+	// set when the game is not played at the home team's usual venue, computed by this service
+	IsNeutralSite bool `json:"isNeutralSite,omitempty"`
 }
 
 type Date struct {
@@ -109,3 +160,44 @@ type ScheduleResponse struct {
 	Dates                []Date  `json:"dates"`
 	Events               []Event `json:"events"`
 }
+
+type StandingsTeamRecord struct {
+	Team   Team `json:"team"`
+	Wins   int  `json:"wins"`
+	Losses int  `json:"losses"`
+}
+
+type StandingsRecord struct {
+	League      League                `json:"league"`
+	Division    Division              `json:"division"`
+	TeamRecords []StandingsTeamRecord `json:"teamRecords"`
+}
+
+type StandingsResponse struct {
+	Copyright string            `json:"copyright"`
+	Records   []StandingsRecord `json:"records"`
+}
+
+type Season struct {
+	SeasonID               string `json:"seasonId"`
+	HasWildcard            bool   `json:"hasWildcard"`
+	PreSeasonStartDate     string `json:"preSeasonStartDate"`
+	SeasonStartDate        string `json:"seasonStartDate"`
+	SpringStartDate        string `json:"springStartDate"`
+	SpringEndDate          string `json:"springEndDate"`
+	RegularSeasonStartDate string `json:"regularSeasonStartDate"`
+	LastDate1stHalf        string `json:"lastDate1stHalf"`
+	AllStarDate            string `json:"allStarDate"`
+	FirstDate2ndHalf       string `json:"firstDate2ndHalf"`
+	RegularSeasonEndDate   string `json:"regularSeasonEndDate"`
+	PostSeasonStartDate    string `json:"postSeasonStartDate"`
+	PostSeasonEndDate      string `json:"postSeasonEndDate"`
+	SeasonEndDate          string `json:"seasonEndDate"`
+	OffseasonStartDate     string `json:"offseasonStartDate"`
+	OffSeasonEndDate       string `json:"offSeasonEndDate"`
+}
+
+type SeasonsResponse struct {
+	Copyright string   `json:"copyright"`
+	Seasons   []Season `json:"seasons"`
+}
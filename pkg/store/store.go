@@ -0,0 +1,233 @@
+package store
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	datesBucket  = []byte("dates")
+	gamesBucket  = []byte("games")
+	teamsBucket  = []byte("teams")
+	venuesBucket = []byte("venues")
+)
+
+// ScheduleStore persists schedules so that historical dates need not be
+// fetched from statsapi on every request
+type ScheduleStore interface {
+	// GetDate returns the games stored for a YYYY-MM-DD date, ok is false when
+	// the date has never been stored
+	GetDate(date string) (d models.Date, ok bool, err error)
+	// PutDate stores a date's games along with the teams and venues they reference
+	PutDate(d models.Date) error
+	// PutTeams stores the team registry, replacing any previously stored teams with the same ID
+	PutTeams(teams []models.Team) error
+	// ListTeams returns every stored team ordered by ID
+	ListTeams() ([]models.Team, error)
+	// GetVenue returns a venue referenced by a stored game
+	GetVenue(id int) (venue models.Venue, ok bool, err error)
+	Close() error
+}
+
+// storedDate lists the games on a date by gamePk, the games themselves are
+// stored in the games bucket keyed by date and gamePk, as a postponed or
+// suspended game keeps its gamePk when it is played on another date
+type storedDate struct {
+	Date    string `json:"date"`
+	GamePks []int  `json:"gamePks"`
+}
+
+// BoltStore is a ScheduleStore backed by an embedded bbolt database
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens, creating if needed, the bbolt database at path
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{datesBucket, gamesBucket, teamsBucket, venuesBucket} {
+			_, err := tx.CreateBucketIfNotExists(bucket)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+func idKey(id int) []byte {
+	return []byte(strconv.Itoa(id))
+}
+
+func gameKey(date string, gamePk int) []byte {
+	return []byte(date + "/" + strconv.Itoa(gamePk))
+}
+
+func put(b *bolt.Bucket, key []byte, v interface{}) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return b.Put(key, value)
+}
+
+func get(b *bolt.Bucket, key []byte, v interface{}) (bool, error) {
+	value := b.Get(key)
+	if value == nil {
+		return false, nil
+	}
+	return true, json.Unmarshal(value, v)
+}
+
+func (s *BoltStore) GetDate(date string) (models.Date, bool, error) {
+	d := models.Date{Date: date, Games: make([]models.Game, 0)}
+	var ok bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var stored storedDate
+		var err error
+		ok, err = get(tx.Bucket(datesBucket), []byte(date), &stored)
+		if err != nil || !ok {
+			return err
+		}
+
+		games := tx.Bucket(gamesBucket)
+		for _, gamePk := range stored.GamePks {
+			var g models.Game
+			found, err := get(games, gameKey(date, gamePk), &g)
+			if err != nil {
+				return err
+			}
+			if !found {
+				// a date stored before games were keyed by date must be fetched again
+				ok = false
+				return nil
+			}
+			d.Games = append(d.Games, g)
+		}
+		return nil
+	})
+	if err != nil || !ok {
+		return models.Date{}, false, err
+	}
+
+	d.TotalGames = uint8(len(d.Games))
+	d.TotalItems = d.TotalGames
+	return d, ok, nil
+}
+
+func (s *BoltStore) PutDate(d models.Date) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		stored := storedDate{Date: d.Date, GamePks: make([]int, 0, len(d.Games))}
+		games := tx.Bucket(gamesBucket)
+		teams := tx.Bucket(teamsBucket)
+		venues := tx.Bucket(venuesBucket)
+		for _, g := range d.Games {
+			stored.GamePks = append(stored.GamePks, g.GamePk)
+			err := put(games, gameKey(d.Date, g.GamePk), g)
+			if err != nil {
+				return err
+			}
+
+			for _, team := range []models.Team{g.Teams.Home.Team, g.Teams.Away.Team} {
+				err = putTeam(teams, team)
+				if err != nil {
+					return err
+				}
+			}
+			if g.Venue.ID != 0 {
+				err = put(venues, idKey(g.Venue.ID), g.Venue)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return put(tx.Bucket(datesBucket), []byte(d.Date), stored)
+	})
+}
+
+// putTeam stores the team referenced by a game, schedule payloads only carry a
+// team's ID, name and link so richer registry fields already stored are kept
+func putTeam(teams *bolt.Bucket, team models.Team) error {
+	if team.ID == 0 {
+		return nil
+	}
+
+	var existing models.Team
+	found, err := get(teams, idKey(team.ID), &existing)
+	if err != nil {
+		return err
+	}
+	if found {
+		existing.Name = team.Name
+		existing.Link = team.Link
+		team = existing
+	}
+	return put(teams, idKey(team.ID), team)
+}
+
+func (s *BoltStore) PutTeams(teams []models.Team) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(teamsBucket)
+		for _, team := range teams {
+			err := put(b, idKey(team.ID), team)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BoltStore) ListTeams() ([]models.Team, error) {
+	teams := make([]models.Team, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(teamsBucket).ForEach(func(k, v []byte) error {
+			var team models.Team
+			err := json.Unmarshal(v, &team)
+			if err != nil {
+				return err
+			}
+			teams = append(teams, team)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(teams, func(i, j int) bool {
+		return teams[i].ID < teams[j].ID
+	})
+	return teams, nil
+}
+
+func (s *BoltStore) GetVenue(id int) (models.Venue, bool, error) {
+	var venue models.Venue
+	var ok bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		ok, err = get(tx.Bucket(venuesBucket), idKey(id), &venue)
+		return err
+	})
+	return venue, ok, err
+}
+
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,98 @@
+package store
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+var _ = Describe("Persisting schedules", Label("Store"), func() {
+	var s *BoltStore
+
+	BeforeEach(func() {
+		var err error
+		s, err = NewBoltStore(filepath.Join(GinkgoT().TempDir(), "test.db"))
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		Expect(s.Close()).To(Succeed())
+	})
+
+	When("We read a date that was never stored", func() {
+		It("should not be found", func(ctx SpecContext) {
+			_, ok, err := s.GetDate("2021-09-11")
+			Expect(err).To(BeNil())
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	When("We store a date", func() {
+		game := models.Game{
+			GamePk:   632980,
+			GameDate: "2021-09-11T23:05:00Z",
+			Status:   models.Status{AbstractGameCode: "F"},
+			Teams: models.Teams{
+				Away: models.ScheduleTeam{Team: models.Team{ID: 147, Name: "New York Yankees"}, Score: 5},
+				Home: models.ScheduleTeam{Team: models.Team{ID: 111, Name: "Boston Red Sox"}, Score: 3},
+			},
+			Venue: models.Venue{ID: 3, Name: "Fenway Park"},
+		}
+
+		It("should return its games", func(ctx SpecContext) {
+			Expect(s.PutDate(models.Date{Date: "2021-09-11", Games: []models.Game{game}})).To(Succeed())
+
+			d, ok, err := s.GetDate("2021-09-11")
+			Expect(err).To(BeNil())
+			Expect(ok).To(BeTrue())
+			Expect(d.TotalGames).To(Equal(uint8(1)))
+			Expect(d.Games).To(Equal([]models.Game{game}))
+		})
+
+		It("should keep a game moved to another date on both dates", func(ctx SpecContext) {
+			postponed := game
+			postponed.Status = models.Status{AbstractGameCode: "F", DetailedState: "Postponed"}
+			makeup := game
+			makeup.GameDate = "2021-09-12T17:05:00Z"
+			makeup.Teams.Away.Score, makeup.Teams.Home.Score = 2, 4
+			Expect(s.PutDate(models.Date{Date: "2021-09-11", Games: []models.Game{postponed}})).To(Succeed())
+			Expect(s.PutDate(models.Date{Date: "2021-09-12", Games: []models.Game{makeup}})).To(Succeed())
+
+			d, ok, err := s.GetDate("2021-09-11")
+			Expect(err).To(BeNil())
+			Expect(ok).To(BeTrue())
+			Expect(d.Games).To(Equal([]models.Game{postponed}))
+
+			d, ok, err = s.GetDate("2021-09-12")
+			Expect(err).To(BeNil())
+			Expect(ok).To(BeTrue())
+			Expect(d.Games).To(Equal([]models.Game{makeup}))
+		})
+
+		It("should store the teams and venues its games reference", func(ctx SpecContext) {
+			Expect(s.PutDate(models.Date{Date: "2021-09-11", Games: []models.Game{game}})).To(Succeed())
+
+			venue, ok, err := s.GetVenue(3)
+			Expect(err).To(BeNil())
+			Expect(ok).To(BeTrue())
+			Expect(venue.Name).To(Equal("Fenway Park"))
+
+			teams, err := s.ListTeams()
+			Expect(err).To(BeNil())
+			Expect(teams).To(ContainElement(HaveField("Name", "Boston Red Sox")))
+		})
+
+		It("should keep registry fields of teams already stored", func(ctx SpecContext) {
+			Expect(s.PutTeams([]models.Team{{ID: 111, Name: "Boston Red Sox", Division: &models.Division{ID: 201}}})).To(Succeed())
+			Expect(s.PutDate(models.Date{Date: "2021-09-11", Games: []models.Game{game}})).To(Succeed())
+
+			teams, err := s.ListTeams()
+			Expect(err).To(BeNil())
+			Expect(teams).To(HaveLen(2))
+			Expect(teams[0].ID).To(Equal(111))
+			Expect(teams[0].Division.ID).To(Equal(201))
+		})
+	})
+})
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"github.com/stefanKnott/mlbtakehome/pkg/cli"
+	"github.com/stefanKnott/mlbtakehome/pkg/handlers"
+	"github.com/stefanKnott/mlbtakehome/pkg/models"
+)
+
+const clearScreen = "\x1b[H\x1b[2J"
+
+// isTerminal reports whether f is attached to a terminal rather than a pipe or file
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// addAbbreviations fills in the registry abbreviation of each team, schedule
+// payloads only carry team names
+func addAbbreviations(schedResp *models.ScheduleResponse) {
+	for i := range schedResp.Dates {
+		for j := range schedResp.Dates[i].Games {
+			teams := &schedResp.Dates[i].Games[j].Teams
+			for _, t := range []*models.Team{&teams.Away.Team, &teams.Home.Team} {
+				team, err := handlers.LookupTeam(strconv.Itoa(t.ID))
+				if err == nil {
+					t.Abbreviation = team.Abbreviation
+				}
+			}
+		}
+	}
+}
+
+func printSchedule(q handlers.ScheduleQuery, asJSON bool, watching bool, color bool) error {
+	schedResp, err := handlers.Schedule(q)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		return json.NewEncoder(os.Stdout).Encode(schedResp)
+	}
+
+	addAbbreviations(&schedResp.ScheduleResponse)
+	if watching && color {
+		fmt.Print(clearScreen)
+	}
+	err = cli.RenderSchedule(os.Stdout, q.TeamID, &schedResp.ScheduleResponse, color)
+	if err != nil {
+		return err
+	}
+	if watching {
+		fmt.Printf("\nupdated %s\n", time.Now().Format("3:04:05 PM"))
+	}
+	return nil
+}
+
+// runSchedule serves `mlbtakehome schedule`, which prints a date's games with
+// the requested team's games first, as the /schedule API orders them
+func runSchedule(args []string) int {
+	flags := flag.NewFlagSet("schedule", flag.ExitOnError)
+	teamParam := flags.String("team", "", "team ID, abbreviation or name, ie. 147, NYY or Yankees")
+	date := flags.String("date", "today", "date of the format YYYY-MM-DD, relative to today (ie. today or +3d) or a season keyword (ie. opening-day)")
+	gameType := flags.String("gameType", "", "comma separated list of game types to include")
+	sort := flags.String("sort", "", "comma separated list of sort strategies for the other games")
+	tz := flags.String("tz", "", "IANA time zone to interpret the date and show start times in")
+	asJSON := flags.Bool("json", false, "print the /schedule API response instead of a scoreboard")
+	watch := flags.Bool("watch", false, "refresh the schedule until interrupted")
+	interval := flags.Duration("interval", 30*time.Second, "how often --watch refreshes")
+	flags.Parse(args)
+
+	if *teamParam == "" || *interval <= 0 {
+		flags.Usage()
+		return 2
+	}
+
+	// the store is optional here, it may be held open by a running server
+	scheduleStore, err := openStore()
+	if err == nil {
+		defer scheduleStore.Close()
+		handlers.SetScheduleStore(scheduleStore)
+	}
+
+	err = handlers.LoadTeams()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
+	// ambiguous names are reported along with the teams they could refer to
+	team, err := handlers.LookupTeam(*teamParam)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+
+	q := handlers.ScheduleQuery{TeamID: team.ID, Date: *date, GameType: *gameType, Sort: *sort, TimeZone: *tz}
+	color := isTerminal(os.Stdout) && os.Getenv("NO_COLOR") == ""
+	if !*watch {
+		err = printSchedule(q, *asJSON, false, color)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for refreshes := 0; ; refreshes++ {
+		err = printSchedule(q, *asJSON, true, color)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			if refreshes == 0 {
+				return 1
+			}
+			// keep watching through transient upstream errors
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0
+		case <-ticker.C:
+		}
+	}
+}